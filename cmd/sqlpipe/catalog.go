@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+	"github.com/sqlpipe/sqlpipe/internal/validator"
+)
+
+func (app *application) listCatalogSchemasHandler(w http.ResponseWriter, r *http.Request) {
+	catalogSearch, release, ok := app.openCatalogSearch(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	schemas, err := catalog.ListSchemas(r.Context(), *catalogSearch)
+	if err != nil {
+		app.errorResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"schemas": schemas}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+func (app *application) listCatalogTablesHandler(w http.ResponseWriter, r *http.Request) {
+	catalogSearch, release, ok := app.openCatalogSearch(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	tables, err := catalog.ListTables(r.Context(), *catalogSearch)
+	if err != nil {
+		app.errorResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"tables": tables}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+func (app *application) listCatalogColumnsHandler(w http.ResponseWriter, r *http.Request) {
+	catalogSearch, release, ok := app.openCatalogSearch(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	columns, err := catalog.ListColumns(r.Context(), *catalogSearch)
+	if err != nil {
+		app.errorResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"columns": columns}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// openCatalogSearch reads and validates a catalog request and gets its
+// source's pool, which release gives back. If it returns false, it has
+// already written the error response.
+func (app *application) openCatalogSearch(w http.ResponseWriter, r *http.Request) (catalogSearch *data.CatalogSearch, release func(), ok bool) {
+	var input struct {
+		Source  data.Source `json:"source"`
+		Catalog string      `json:"catalog"`
+		Schema  string      `json:"schema"`
+		Table   string      `json:"table"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return nil, nil, false
+	}
+
+	catalogSearch = &data.CatalogSearch{
+		Source:  input.Source,
+		Catalog: input.Catalog,
+		Schema:  input.Schema,
+		Table:   input.Table,
+	}
+
+	v := validator.New()
+	app.connections.ResolveSource(v, &catalogSearch.Source)
+	app.secrets.ResolveSource(v, &catalogSearch.Source)
+	if data.ValidateCatalogSearch(v, catalogSearch); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return nil, nil, false
+	}
+
+	catalogSearch.Source.Db, release, err = app.pools.Get(r.Context(), catalogSearch.Source.Dsn())
+	if err != nil {
+		app.errorResponse(w, r, http.StatusBadRequest, err)
+		return nil, nil, false
+	}
+
+	return catalogSearch, release, true
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+	"github.com/sqlpipe/sqlpipe/internal/validator"
+)
+
+func (app *application) createConnectionHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name       string           `json:"name"`
+		SystemType string           `json:"system_type"`
+		OdbcDsn    string           `json:"odbc_dsn"`
+		Connection *data.Connection `json:"connection"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	namedConnection := data.NamedConnection{
+		Name:       input.Name,
+		SystemType: input.SystemType,
+		OdbcDsn:    input.OdbcDsn,
+		Connection: input.Connection,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	v := validator.New()
+	if data.ValidateNamedConnection(v, &namedConnection); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.connections.Insert(namedConnection)
+	if errors.Is(err, data.ErrDuplicateConnection) {
+		v.AddError("name", err.Error())
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = app.respondWithJSON(w, http.StatusCreated, map[string]any{"connection": namedConnection.Redacted()}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+func (app *application) listConnectionsHandler(w http.ResponseWriter, r *http.Request) {
+	namedConnections, err := app.connections.List()
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	for i := range namedConnections {
+		namedConnections[i] = namedConnections[i].Redacted()
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"connections": namedConnections}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+func (app *application) showConnectionHandler(w http.ResponseWriter, r *http.Request) {
+	name := httprouter.ParamsFromContext(r.Context()).ByName("name")
+
+	namedConnection, err := app.connections.Get(name)
+	if errors.Is(err, data.ErrConnectionNotFound) {
+		app.notFoundResponse(w, r)
+		return
+	}
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"connection": namedConnection.Redacted()}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+func (app *application) deleteConnectionHandler(w http.ResponseWriter, r *http.Request) {
+	name := httprouter.ParamsFromContext(r.Context()).ByName("name")
+
+	err := app.connections.Delete(name)
+	if errors.Is(err, data.ErrConnectionNotFound) {
+		app.notFoundResponse(w, r)
+		return
+	}
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"message": "connection deleted"}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// testConnectionHandler connects to a source on its own connection, outside
+// the shared pools, and reports what the driver says about it. Failing to
+// connect is reported in the response rather than as an error status.
+func (app *application) testConnectionHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Source data.Source `json:"source"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	source := input.Source
+
+	v := validator.New()
+	app.connections.ResolveSource(v, &source)
+	app.secrets.ResolveSource(v, &source)
+	if data.ValidateSource(v, source); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	failed := func(err error) {
+		err = app.respondWithJSON(w, http.StatusOK, map[string]any{"ok": false, "error": catalog.NewDiagnosticError(err)}, make(http.Header))
+		if err != nil {
+			app.errorResponse(w, r, http.StatusInternalServerError, err)
+		}
+	}
+
+	db, err := sql.Open("odbc", source.Dsn())
+	if err != nil {
+		failed(err)
+		return
+	}
+	defer db.Close()
+
+	// connects, so the latency below doesn't include connecting
+	err = db.PingContext(r.Context())
+	if err != nil {
+		failed(err)
+		return
+	}
+	latency, err := catalog.Latency(r.Context(), db, source.SystemType)
+	if err != nil {
+		failed(err)
+		return
+	}
+
+	diagnostics, err := catalog.Diagnose(r.Context(), db, source.SystemType)
+	if err != nil {
+		failed(err)
+		return
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"ok": true, "diagnostics": diagnostics, "latency": latency.String()}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"database/sql"
 	"fmt"
 	"io"
 	"net/http"
@@ -38,27 +37,20 @@ func (app *application) runCsvSaveOnServerHandler(w http.ResponseWriter, r *http
 	}
 
 	v := validator.New()
+	app.connections.ResolveSource(v, &export.Source)
+	app.secrets.ResolveSource(v, &export.Source)
 	if data.ValidateCsvSave(v, export); !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	var sourceDb *sql.DB
-	sourceDb, err = sql.Open(
-		"odbc",
-		export.Source.OdbcDsn,
-	)
+	sourceDb, releaseSource, err := app.pools.Get(r.Context(), export.Source.Dsn())
 	if err != nil {
 		app.errorResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
-
+	defer releaseSource()
 	export.Source.Db = sourceDb
-	err = export.Source.Db.Ping()
-	if err != nil {
-		app.errorResponse(w, r, http.StatusBadRequest, err)
-		return
-	}
 
 	_, err = os.Stat(export.WriteLocation)
 	if err == nil {
@@ -105,27 +97,20 @@ func (app *application) runCsvDownloadHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	v := validator.New()
+	app.connections.ResolveSource(v, &export.Source)
+	app.secrets.ResolveSource(v, &export.Source)
 	if data.ValidateCsvSaveNoWriteLocation(v, export); !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	var sourceDb *sql.DB
-	sourceDb, err = sql.Open(
-		"odbc",
-		export.Source.OdbcDsn,
-	)
+	sourceDb, releaseSource, err := app.pools.Get(r.Context(), export.Source.Dsn())
 	if err != nil {
 		app.errorResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
-
+	defer releaseSource()
 	export.Source.Db = sourceDb
-	err = export.Source.Db.Ping()
-	if err != nil {
-		app.errorResponse(w, r, http.StatusBadRequest, err)
-		return
-	}
 
 	file, err := os.CreateTemp("", "")
 	if err != nil {
@@ -170,13 +155,16 @@ func (app *application) runCsvS3UploadHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	v := validator.New()
+	app.connections.ResolveSource(v, &s3Upload.Source)
+	app.secrets.ResolveSource(v, &s3Upload.Source)
+	app.secrets.ResolveS3Upload(v, s3Upload)
 	if data.ValidateS3Upload(v, s3Upload); !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
 	export := &data.CsvSave{
-		Source: input.Source,
+		Source: s3Upload.Source,
 		Query:  input.Query,
 	}
 
@@ -185,22 +173,13 @@ func (app *application) runCsvS3UploadHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	var sourceDb *sql.DB
-	sourceDb, err = sql.Open(
-		"odbc",
-		export.Source.OdbcDsn,
-	)
+	sourceDb, releaseSource, err := app.pools.Get(r.Context(), export.Source.Dsn())
 	if err != nil {
 		app.errorResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
-
+	defer releaseSource()
 	export.Source.Db = sourceDb
-	err = export.Source.Db.Ping()
-	if err != nil {
-		app.errorResponse(w, r, http.StatusBadRequest, err)
-		return
-	}
 
 	file, err := os.CreateTemp("", "")
 	if err != nil {
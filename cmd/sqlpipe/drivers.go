@@ -0,0 +1,20 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+)
+
+func (app *application) listDriversHandler(w http.ResponseWriter, r *http.Request) {
+	drivers, dataSources, err := catalog.ListDrivers()
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"drivers": drivers, "data_sources": dataSources}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
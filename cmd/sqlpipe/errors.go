@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/sqlpipe/odbc"
+	"github.com/sqlpipe/sqlpipe/internal/data"
 )
 
 func (app *application) logError(r *http.Request, err error) {
@@ -59,3 +63,42 @@ func (app *application) invalidAuthenticationTokenResponse(w http.ResponseWriter
 	message := errors.New("invalid or missing authentication token")
 	app.errorResponse(w, r, http.StatusUnauthorized, message)
 }
+
+// runErrorResponse reports an error from running a request, as a timeout if
+// one of the request's timeouts cut it short.
+func (app *application) runErrorResponse(w http.ResponseWriter, r *http.Request, ctx context.Context, timeouts data.Timeouts, err error) {
+	timeout := ""
+	switch {
+	case timeouts.TotalTimeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded):
+		timeout = "total_timeout"
+	case timeouts.QueryTimeout > 0 && hasSqlState(err, "HYT00"):
+		timeout = "query_timeout"
+	default:
+		app.errorResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	app.logError(r, err)
+	env := map[string]any{"error": fmt.Sprintf("request exceeded its %v: %v", timeout, err), "timeout": timeout}
+
+	err = app.respondWithJSON(w, http.StatusGatewayTimeout, env, nil)
+	if err != nil {
+		app.logError(r, err)
+		w.WriteHeader(500)
+	}
+}
+
+// hasSqlState reports whether err wraps a driver error with a diagnostic
+// record in sqlState.
+func hasSqlState(err error, sqlState string) bool {
+	var odbcErr *odbc.Error
+	if !errors.As(err, &odbcErr) {
+		return false
+	}
+	for _, diag := range odbcErr.Diag {
+		if diag.State == sqlState {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,10 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
+
+	"github.com/sqlpipe/odbc"
+	"github.com/sqlpipe/sqlpipe/internal/data"
 )
 
 func (app *application) respondWithJSON(w http.ResponseWriter, status int, data map[string]any, headers http.Header) error {
@@ -111,3 +116,15 @@ func (app *application) readJSON(w http.ResponseWriter, r *http.Request, dst any
 
 	return nil
 }
+
+// withTimeouts applies a request's timeouts to ctx. The query timeout is
+// passed to the odbc driver, which sets it on each statement.
+func withTimeouts(ctx context.Context, timeouts data.Timeouts) (context.Context, context.CancelFunc) {
+	if timeouts.QueryTimeout > 0 {
+		ctx = odbc.WithQueryTimeout(ctx, time.Duration(timeouts.QueryTimeout)*time.Second)
+	}
+	if timeouts.TotalTimeout > 0 {
+		return context.WithTimeout(ctx, time.Duration(timeouts.TotalTimeout)*time.Second)
+	}
+	return context.WithCancel(ctx)
+}
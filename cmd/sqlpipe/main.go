@@ -1,17 +1,24 @@
 package main
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"expvar"
 	"flag"
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 	"unicode/utf8"
 
 	_ "github.com/sqlpipe/odbc"
 
+	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/pools"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/dialects"
 	"github.com/sqlpipe/sqlpipe/internal/jsonLog"
 	"github.com/sqlpipe/sqlpipe/internal/vcs"
 	"github.com/sqlpipe/sqlpipe/pkg"
@@ -22,9 +29,21 @@ var (
 )
 
 type appConfig struct {
-	port    int
-	token   string
-	secure  bool
+	port              int
+	token             string
+	secure            bool
+	typeMappingsFile  string
+	connectionsFile   string
+	connectionsKey    string
+	secretEnvPrefixes string
+	secretDirs        string
+	db                struct {
+		maxOpenConns    int
+		maxIdleConns    int
+		maxIdleTime     time.Duration
+		poolIdleTimeout time.Duration
+		fetchSize       int
+	}
 	limiter struct {
 		enabled bool
 		rps     float64
@@ -33,9 +52,13 @@ type appConfig struct {
 }
 
 type application struct {
-	config appConfig
-	logger *jsonLog.Logger
-	wg     sync.WaitGroup
+	config       appConfig
+	logger       *jsonLog.Logger
+	typeMappings dialects.TypeMappings
+	connections  *data.ConnectionRegistry
+	secrets      *data.SecretResolver
+	pools        *pools.Manager
+	wg           sync.WaitGroup
 }
 
 func main() {
@@ -47,9 +70,23 @@ func main() {
 	flag.Float64Var(&cfg.limiter.rps, "limiter-rps", 10, "Rate limiter maximum requests per second")
 	flag.IntVar(&cfg.limiter.burst, "limiter-burst", 100, "Rate limiter maximum burst")
 
+	flag.IntVar(&cfg.db.maxOpenConns, "db-max-open-conns", 0, "Maximum open connections per database, or 0 for no limit")
+	flag.IntVar(&cfg.db.maxIdleConns, "db-max-idle-conns", 2, "Maximum idle connections per database")
+	flag.DurationVar(&cfg.db.maxIdleTime, "db-max-idle-time", 5*time.Minute, "How long a connection may sit idle before it is closed")
+	flag.DurationVar(&cfg.db.poolIdleTimeout, "db-pool-idle-timeout", 15*time.Minute, "How long a database may go unused before its connection pool is closed")
+	flag.IntVar(&cfg.db.fetchSize, "db-fetch-size", 100, "Rows fetched per round trip, unless a DSN sets FetchSize")
+
 	flag.StringVar(&cfg.token, "token", "", "Auth token")
 	flag.BoolVar(&cfg.secure, "secure", false, "Secure with an auth token")
 
+	flag.StringVar(&cfg.typeMappingsFile, "type-mappings", "", "JSON file of DDL templates for source column types, keyed by target system type")
+
+	flag.StringVar(&cfg.connectionsFile, "connections-file", "", "File to save named connections in, so they last between restarts")
+	flag.StringVar(&cfg.connectionsKey, "connections-key", os.Getenv("SQLPIPE_CONNECTIONS_KEY"), "Key encrypting named connection credentials, as 64 hex characters")
+
+	flag.StringVar(&cfg.secretEnvPrefixes, "secret-env-prefixes", "", "Comma separated prefixes of environment variables requests may reference with ${env:NAME}")
+	flag.StringVar(&cfg.secretDirs, "secret-dirs", "", "Comma separated directories whose files requests may reference with ${file:/path}")
+
 	displayVersion := flag.Bool("version", false, "Display version and exit")
 
 	flag.Parse()
@@ -78,6 +115,49 @@ func main() {
 
 	logger := jsonLog.New(os.Stdout, jsonLog.LevelInfo)
 
+	typeMappings := dialects.TypeMappings{}
+	if cfg.typeMappingsFile != "" {
+		var err error
+		typeMappings, err = dialects.LoadTypeMappings(cfg.typeMappingsFile)
+		if err != nil {
+			logger.PrintFatal(err, nil)
+		}
+	}
+
+	connectionsKey, err := hex.DecodeString(cfg.connectionsKey)
+	if err != nil || (cfg.connectionsKey != "" && len(connectionsKey) != 32) {
+		logger.PrintFatal(errors.New("invalid connections-key value (must be exactly 64 hex characters)"), nil)
+	}
+	if cfg.connectionsKey == "" {
+		if cfg.connectionsFile != "" {
+			logger.PrintFatal(errors.New("connections-key must be provided with connections-file"), nil)
+		}
+		// connections only live as long as the server, so any key will do
+		connectionsKey = make([]byte, 32)
+		_, err = rand.Read(connectionsKey)
+		if err != nil {
+			logger.PrintFatal(fmt.Errorf("error generating connections key: %v", err), nil)
+		}
+	}
+	connections, err := data.NewConnectionRegistry(cfg.connectionsFile, connectionsKey)
+	if err != nil {
+		logger.PrintFatal(err, nil)
+	}
+
+	secrets := &data.SecretResolver{
+		EnvPrefixes: splitList(cfg.secretEnvPrefixes),
+		Dirs:        splitList(cfg.secretDirs),
+	}
+
+	poolManager := pools.New(pools.Config{
+		MaxOpenConns:    cfg.db.maxOpenConns,
+		MaxIdleConns:    cfg.db.maxIdleConns,
+		MaxIdleTime:     cfg.db.maxIdleTime,
+		PoolIdleTimeout: cfg.db.poolIdleTimeout,
+		FetchSize:       cfg.db.fetchSize,
+		OnClose:         dialects.ForgetServerVersion,
+	})
+
 	expvar.NewString("version").Set(version)
 
 	expvar.Publish("goroutines", expvar.Func(func() any {
@@ -88,13 +168,33 @@ func main() {
 		return time.Now().Unix()
 	}))
 
+	expvar.Publish("database", expvar.Func(func() any {
+		return poolManager.Stats()
+	}))
+
 	app := &application{
-		config: cfg,
-		logger: logger,
+		config:       cfg,
+		logger:       logger,
+		typeMappings: typeMappings,
+		connections:  connections,
+		secrets:      secrets,
+		pools:        poolManager,
 	}
 
-	err := app.serve()
+	err = app.serve()
 	if err != nil {
 		logger.PrintFatal(err, nil)
 	}
 }
+
+// splitList splits a comma separated flag value, dropping empty entries.
+func splitList(list string) []string {
+	values := []string{}
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"database/sql"
 	"net/http"
 
 	"github.com/shomali11/xsql"
@@ -13,6 +12,7 @@ func (app *application) runQueryHandler(w http.ResponseWriter, r *http.Request)
 	var input struct {
 		Source data.Source `json:"source"`
 		Query  string      `json:"query"`
+		data.Timeouts
 	}
 
 	err := app.readJSON(w, r, &input)
@@ -22,41 +22,39 @@ func (app *application) runQueryHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	query := &data.Query{
-		Source: input.Source,
-		Query:  input.Query,
+		Source:   input.Source,
+		Query:    input.Query,
+		Timeouts: input.Timeouts,
 	}
 
 	v := validator.New()
+	app.connections.ResolveSource(v, &query.Source)
+	app.secrets.ResolveSource(v, &query.Source)
 	if data.ValidateQuery(v, query); !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	sourceDb, err := sql.Open(
-		"odbc",
-		query.Source.OdbcDsn,
-	)
-	if err != nil {
-		app.errorResponse(w, r, http.StatusBadRequest, err)
-		return
-	}
+	ctx, cancel := withTimeouts(r.Context(), query.Timeouts)
+	defer cancel()
 
-	query.Source.Db = sourceDb
-	err = query.Source.Db.Ping()
+	sourceDb, releaseSource, err := app.pools.Get(ctx, query.Source.Dsn())
 	if err != nil {
 		app.errorResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
+	defer releaseSource()
+	query.Source.Db = sourceDb
 
 	message := ""
 
-	rows, err := query.Source.Db.QueryContext(r.Context(), query.Query)
+	rows, err := query.Source.Db.QueryContext(ctx, query.Query)
 	if err != nil {
 		switch {
 		case err.Error() == "Stmt did not create a result set":
 			message = "Query ran successfully but did not product a result set."
 		default:
-			app.errorResponse(w, r, http.StatusBadRequest, err)
+			app.runErrorResponse(w, r, ctx, query.Timeouts, err)
 			return
 		}
 	}
@@ -17,9 +17,19 @@ func (app *application) routes() http.Handler {
 
 	router.HandlerFunc(http.MethodPost, "/v2/query", app.authenticate(app.runQueryHandler))
 	router.HandlerFunc(http.MethodPost, "/v2/transfer", app.authenticate(app.runTransferHandler))
+	router.HandlerFunc(http.MethodPost, "/v2/transfer/schema", app.authenticate(app.runSchemaTransferHandler))
 	router.HandlerFunc(http.MethodPost, "/v2/csv/download", app.authenticate(app.runCsvDownloadHandler))
 	router.HandlerFunc(http.MethodPost, "/v2/csv/s3", app.authenticate(app.runCsvS3UploadHandler))
 	router.HandlerFunc(http.MethodPost, "/v2/csv/save", app.authenticate(app.runCsvSaveOnServerHandler))
+	router.HandlerFunc(http.MethodPost, "/v2/catalog/schemas", app.authenticate(app.listCatalogSchemasHandler))
+	router.HandlerFunc(http.MethodPost, "/v2/catalog/tables", app.authenticate(app.listCatalogTablesHandler))
+	router.HandlerFunc(http.MethodPost, "/v2/catalog/columns", app.authenticate(app.listCatalogColumnsHandler))
+	router.HandlerFunc(http.MethodGet, "/v2/drivers", app.authenticate(app.listDriversHandler))
+	router.HandlerFunc(http.MethodPost, "/v2/connections", app.authenticate(app.createConnectionHandler))
+	router.HandlerFunc(http.MethodGet, "/v2/connections", app.authenticate(app.listConnectionsHandler))
+	router.HandlerFunc(http.MethodPost, "/v2/connections/test", app.authenticate(app.testConnectionHandler))
+	router.HandlerFunc(http.MethodGet, "/v2/connections/:name", app.authenticate(app.showConnectionHandler))
+	router.HandlerFunc(http.MethodDelete, "/v2/connections/:name", app.authenticate(app.deleteConnectionHandler))
 
 	router.Handler(http.MethodGet, "/debug/vars", expvar.Handler())
 
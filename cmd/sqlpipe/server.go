@@ -42,6 +42,13 @@ func (app *application) serve() error {
 		})
 
 		app.wg.Wait()
+
+		err = app.pools.Close()
+		if err != nil {
+			shutdownError <- err
+			return
+		}
+
 		shutdownError <- nil
 	}()
 
@@ -1,21 +1,26 @@
 package main
 
 import (
-	"database/sql"
 	"net/http"
+	"strings"
 
 	"github.com/sqlpipe/sqlpipe/internal/data"
 	"github.com/sqlpipe/sqlpipe/internal/engine/transfers"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/dialects"
 	"github.com/sqlpipe/sqlpipe/internal/validator"
 )
 
 func (app *application) runTransferHandler(w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		Source            data.Source `json:"source"`
-		Target            data.Target `json:"target"`
-		Query             string      `json:"query"`
-		DropTargetTable   bool        `json:"drop_target_table"`
-		CreateTargetTable bool        `json:"create_target_table"`
+		Source            data.Source       `json:"source"`
+		Target            data.Target       `json:"target"`
+		Query             string            `json:"query"`
+		SourceTable       *data.SourceTable `json:"source_table"`
+		DropTargetTable   bool              `json:"drop_target_table"`
+		CreateTargetTable bool              `json:"create_target_table"`
+		TypeMappings      map[string]string `json:"type_mappings"`
+		InsertMethod      string            `json:"insert_method"`
+		data.Timeouts
 	}
 
 	err := app.readJSON(w, r, &input)
@@ -28,54 +33,151 @@ func (app *application) runTransferHandler(w http.ResponseWriter, r *http.Reques
 		Source:            input.Source,
 		Target:            input.Target,
 		Query:             input.Query,
+		SourceTable:       input.SourceTable,
 		DropTargetTable:   input.DropTargetTable,
 		CreateTargetTable: input.CreateTargetTable,
+		TypeMappings:      input.TypeMappings,
+		InsertMethod:      input.InsertMethod,
+		Timeouts:          input.Timeouts,
 	}
 
 	v := validator.New()
-	if data.ValidateTransfer(v, transfer); !v.Valid() {
+	app.connections.ResolveSource(v, &transfer.Source)
+	app.secrets.ResolveSource(v, &transfer.Source)
+	app.connections.ResolveTarget(v, &transfer.Target)
+	app.secrets.ResolveTarget(v, &transfer.Target)
+	data.ValidateTransfer(v, transfer, dialects.Names())
+	validateTypeMappings(v, transfer.TypeMappings)
+	if !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
 		return
 	}
 
-	transfer.Source.Db, err = sql.Open(
-		"odbc",
-		transfer.Source.OdbcDsn,
-	)
+	transfer.TypeMappings = dialects.MergeTypeMappings(app.typeMappings[transfer.Target.SystemType], transfer.TypeMappings)
+
+	ctx, cancel := withTimeouts(r.Context(), transfer.Timeouts)
+	defer cancel()
+
+	sourceDb, releaseSource, err := app.pools.Get(ctx, transfer.Source.Dsn())
 	if err != nil {
 		app.errorResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
+	defer releaseSource()
+	transfer.Source.Db = sourceDb
 
-	err = transfer.Source.Db.Ping()
+	targetDb, releaseTarget, err := app.pools.Get(ctx, transfer.Target.Dsn())
 	if err != nil {
 		app.errorResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
+	defer releaseTarget()
+	transfer.Target.Db = targetDb
 
-	transfer.Target.Db, err = sql.Open(
-		"odbc",
-		transfer.Target.OdbcDsn,
-	)
+	err = transfers.RunTransfer(ctx, *transfer)
 	if err != nil {
-		app.errorResponse(w, r, http.StatusBadRequest, err)
+		app.runErrorResponse(w, r, ctx, transfer.Timeouts, err)
+		return
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"message": "success"}, make(http.Header))
+	if err != nil {
+		app.errorResponse(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+func (app *application) runSchemaTransferHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Source             data.Source       `json:"source"`
+		Target             data.Target       `json:"target"`
+		SourceSchema       string            `json:"source_schema"`
+		Include            []string          `json:"include"`
+		Exclude            []string          `json:"exclude"`
+		DropTargetTables   bool              `json:"drop_target_tables"`
+		CreateTargetTables bool              `json:"create_target_tables"`
+		CreateForeignKeys  bool              `json:"create_foreign_keys"`
+		TypeMappings       map[string]string `json:"type_mappings"`
+		Concurrency        int               `json:"concurrency"`
+		ContinueOnError    bool              `json:"continue_on_error"`
+		InsertMethod       string            `json:"insert_method"`
+		data.Timeouts
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
-	err = transfer.Target.Db.Ping()
+	schemaTransfer := &data.SchemaTransfer{
+		Source:             input.Source,
+		Target:             input.Target,
+		SourceSchema:       input.SourceSchema,
+		Include:            input.Include,
+		Exclude:            input.Exclude,
+		DropTargetTables:   input.DropTargetTables,
+		CreateTargetTables: input.CreateTargetTables,
+		CreateForeignKeys:  input.CreateForeignKeys,
+		TypeMappings:       input.TypeMappings,
+		Concurrency:        input.Concurrency,
+		ContinueOnError:    input.ContinueOnError,
+		InsertMethod:       input.InsertMethod,
+		Timeouts:           input.Timeouts,
+	}
+
+	v := validator.New()
+	app.connections.ResolveSource(v, &schemaTransfer.Source)
+	app.secrets.ResolveSource(v, &schemaTransfer.Source)
+	app.connections.ResolveTarget(v, &schemaTransfer.Target)
+	app.secrets.ResolveTarget(v, &schemaTransfer.Target)
+	data.ValidateSchemaTransfer(v, schemaTransfer, dialects.Names())
+	validateTypeMappings(v, schemaTransfer.TypeMappings)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	schemaTransfer.TypeMappings = dialects.MergeTypeMappings(app.typeMappings[schemaTransfer.Target.SystemType], schemaTransfer.TypeMappings)
+
+	ctx, cancel := withTimeouts(r.Context(), schemaTransfer.Timeouts)
+	defer cancel()
+
+	sourceDb, releaseSource, err := app.pools.Get(ctx, schemaTransfer.Source.Dsn())
 	if err != nil {
 		app.errorResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
+	defer releaseSource()
+	schemaTransfer.Source.Db = sourceDb
 
-	err = transfers.RunTransfer(r.Context(), *transfer)
+	targetDb, releaseTarget, err := app.pools.Get(ctx, schemaTransfer.Target.Dsn())
 	if err != nil {
 		app.errorResponse(w, r, http.StatusBadRequest, err)
 		return
 	}
+	defer releaseTarget()
+	schemaTransfer.Target.Db = targetDb
 
-	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"message": "success"}, make(http.Header))
+	report, err := transfers.RunSchemaTransfer(ctx, *schemaTransfer)
+	if err != nil {
+		app.runErrorResponse(w, r, ctx, schemaTransfer.Timeouts, err)
+		return
+	}
+
+	counts := map[string]int{"succeeded": 0, "failed": 0, "skipped": 0}
+	for _, result := range report.Tables {
+		counts[result.Status]++
+	}
+
+	err = app.respondWithJSON(w, http.StatusOK, map[string]any{"report": report, "counts": counts}, make(http.Header))
 	if err != nil {
 		app.errorResponse(w, r, http.StatusInternalServerError, err)
 	}
 }
+
+// validateTypeMappings checks the type mappings given with a request, which
+// are kept by the dialects package.
+func validateTypeMappings(v *validator.Validator, mappings map[string]string) {
+	problems := dialects.ValidateTypeMappings(mappings)
+	v.Check(len(problems) == 0, "type_mappings", strings.Join(problems, "; "))
+}
@@ -0,0 +1,19 @@
+package data
+
+import (
+	"github.com/sqlpipe/sqlpipe/internal/validator"
+)
+
+// CatalogSearch narrows a catalog listing. Schema and Table are ODBC search
+// patterns, where % matches any run of characters and _ matches one, and empty
+// patterns match everything.
+type CatalogSearch struct {
+	Source  Source `json:"source"`
+	Catalog string `json:"catalog"`
+	Schema  string `json:"schema"`
+	Table   string `json:"table"`
+}
+
+func ValidateCatalogSearch(v *validator.Validator, catalogSearch *CatalogSearch) {
+	ValidateSource(v, catalogSearch.Source)
+}
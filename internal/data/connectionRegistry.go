@@ -0,0 +1,413 @@
+package data
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sqlpipe/sqlpipe/internal/validator"
+)
+
+var (
+	ErrDuplicateConnection = errors.New("a connection with that name already exists")
+	ErrConnectionNotFound  = errors.New("no connection with that name exists")
+)
+
+var ConnectionNameRX = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,100}$`)
+
+const redacted = "********"
+
+// NamedConnection is a source or target stored on the server, so requests can
+// refer to it by name instead of carrying its credentials.
+type NamedConnection struct {
+	Name       string      `json:"name"`
+	SystemType string      `json:"system_type"`
+	OdbcDsn    string      `json:"odbc_dsn,omitempty"`
+	Connection *Connection `json:"connection,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+func ValidateNamedConnection(v *validator.Validator, namedConnection *NamedConnection) {
+	v.Check(validator.Matches(namedConnection.Name, ConnectionNameRX), "name", "must be 1 to 100 letters, digits, _ or -")
+	v.Check(namedConnection.OdbcDsn != "" || namedConnection.Connection != nil, "odbc_dsn", "must be provided, or connection instead")
+	v.Check(namedConnection.OdbcDsn == "" || namedConnection.Connection == nil, "connection", "must not be provided with odbc_dsn")
+	if namedConnection.SystemType != "" {
+		v.Check(validator.PermittedValue(namedConnection.SystemType, ConnectionSystemTypes()...), "system_type", fmt.Sprintf("must be one of %v", strings.Join(ConnectionSystemTypes(), ", ")))
+	}
+	if namedConnection.Connection != nil {
+		v.Check(namedConnection.SystemType != "", "system_type", "must be provided with connection")
+		if namedConnection.SystemType != "" {
+			ValidateConnection(v, "connection", namedConnection.SystemType, *namedConnection.Connection)
+		}
+	}
+}
+
+// Redacted returns the connection with its password, secret looking options
+// and DSN attributes replaced, for responses.
+func (nc NamedConnection) Redacted() NamedConnection {
+	nc.OdbcDsn = RedactDsn(nc.OdbcDsn)
+	if nc.Connection != nil {
+		connection := *nc.Connection
+		if connection.Password != "" {
+			connection.Password = redacted
+		}
+		if connection.Options != nil {
+			connection.Options = map[string]string{}
+			for key, value := range nc.Connection.Options {
+				if isSecretKey(key) {
+					value = redacted
+				}
+				connection.Options[key] = value
+			}
+		}
+		nc.Connection = &connection
+	}
+	return nc
+}
+
+// RedactDsn replaces the values of password, secret and token attributes in
+// an ODBC connection string.
+func RedactDsn(dsn string) string {
+	var redactedDsn strings.Builder
+	for dsn != "" {
+		end := strings.IndexByte(dsn, '=')
+		if end < 0 {
+			redactedDsn.WriteString(dsn)
+			break
+		}
+		key := dsn[:end]
+		dsn = dsn[end+1:]
+
+		// braced values run to the first single closing brace
+		end = strings.IndexByte(dsn, ';')
+		if strings.HasPrefix(dsn, "{") {
+			for i := 1; i < len(dsn); i++ {
+				if dsn[i] != '}' {
+					continue
+				}
+				if i+1 < len(dsn) && dsn[i+1] == '}' {
+					i++
+					continue
+				}
+				end = strings.IndexByte(dsn[i:], ';')
+				if end >= 0 {
+					end += i
+				}
+				break
+			}
+		}
+		value := dsn
+		dsn = ""
+		if end >= 0 {
+			value, dsn = value[:end], value[end+1:]
+		}
+		if isSecretKey(key) {
+			value = redacted
+		}
+		redactedDsn.WriteString(key + "=" + value)
+		if end >= 0 {
+			redactedDsn.WriteString(";")
+		}
+	}
+	return redactedDsn.String()
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for _, secret := range []string{"pwd", "password", "secret", "token"} {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConnectionRegistry keeps named connections, encrypting their DSNs,
+// passwords and options with AES-GCM under the server key. With a path, it is
+// saved to a file after each change and loaded from it on start.
+type ConnectionRegistry struct {
+	mu          sync.RWMutex
+	path        string
+	aead        cipher.AEAD
+	connections map[string]storedConnection
+}
+
+// storedConnection is a NamedConnection with the secret parts encrypted.
+type storedConnection struct {
+	Name       string      `json:"name"`
+	SystemType string      `json:"system_type"`
+	Connection *Connection `json:"connection,omitempty"`
+	Secret     string      `json:"secret"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type connectionSecret struct {
+	OdbcDsn  string            `json:"odbc_dsn,omitempty"`
+	Password string            `json:"password,omitempty"`
+	Options  map[string]string `json:"options,omitempty"`
+}
+
+// NewConnectionRegistry creates a registry encrypting with a 32 byte key,
+// loading the connections saved at path if there are any.
+func NewConnectionRegistry(path string, key []byte) (*ConnectionRegistry, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating connection cipher: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating connection cipher: %v", err)
+	}
+	registry := &ConnectionRegistry{
+		path:        path,
+		aead:        aead,
+		connections: map[string]storedConnection{},
+	}
+	if path == "" {
+		return registry, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading connections file: %v", err)
+	}
+	storedConnections := []storedConnection{}
+	err = json.Unmarshal(contents, &storedConnections)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing connections file: %v", err)
+	}
+	for _, stored := range storedConnections {
+		// a wrong key would otherwise only show up when the connection is used
+		_, err = registry.open(stored)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting connection %v, check the connections key: %v", stored.Name, err)
+		}
+		registry.connections[stored.Name] = stored
+	}
+	return registry, nil
+}
+
+func (r *ConnectionRegistry) Insert(namedConnection NamedConnection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.connections[namedConnection.Name]; ok {
+		return ErrDuplicateConnection
+	}
+	stored, err := r.seal(namedConnection)
+	if err != nil {
+		return err
+	}
+	r.connections[namedConnection.Name] = stored
+	err = r.save()
+	if err != nil {
+		delete(r.connections, namedConnection.Name)
+		return err
+	}
+	return nil
+}
+
+func (r *ConnectionRegistry) Get(name string) (NamedConnection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.connections[name]
+	if !ok {
+		return NamedConnection{}, ErrConnectionNotFound
+	}
+	return r.open(stored)
+}
+
+// List returns every connection, ordered by name.
+func (r *ConnectionRegistry) List() ([]NamedConnection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	namedConnections := []NamedConnection{}
+	for _, stored := range r.connections {
+		namedConnection, err := r.open(stored)
+		if err != nil {
+			return nil, err
+		}
+		namedConnections = append(namedConnections, namedConnection)
+	}
+	sort.Slice(namedConnections, func(i, j int) bool {
+		return namedConnections[i].Name < namedConnections[j].Name
+	})
+	return namedConnections, nil
+}
+
+func (r *ConnectionRegistry) Delete(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.connections[name]
+	if !ok {
+		return ErrConnectionNotFound
+	}
+	delete(r.connections, name)
+	err := r.save()
+	if err != nil {
+		r.connections[name] = stored
+		return err
+	}
+	return nil
+}
+
+// ResolveSource fills in a source given by connection name from the registry.
+func (r *ConnectionRegistry) ResolveSource(v *validator.Validator, source *Source) {
+	if source.ConnectionName == "" {
+		return
+	}
+	v.Check(source.OdbcDsn == "" && source.Connection == nil && source.SystemType == "", "source->connection_name", "must not be provided with source->odbc_dsn, source->connection or source->system_type")
+	namedConnection, err := r.Get(source.ConnectionName)
+	if err != nil {
+		v.AddError("source->connection_name", err.Error())
+		return
+	}
+	source.OdbcDsn = namedConnection.OdbcDsn
+	source.Connection = namedConnection.Connection
+	source.SystemType = namedConnection.SystemType
+}
+
+// ResolveTarget fills in a target given by connection name from the registry.
+// The target's system type defaults to the connection's.
+func (r *ConnectionRegistry) ResolveTarget(v *validator.Validator, target *Target) {
+	if target.ConnectionName == "" {
+		return
+	}
+	v.Check(target.OdbcDsn == "" && target.Connection == nil, "target->connection_name", "must not be provided with target->odbc_dsn or target->connection")
+	namedConnection, err := r.Get(target.ConnectionName)
+	if err != nil {
+		v.AddError("target->connection_name", err.Error())
+		return
+	}
+	if target.SystemType == "" {
+		target.SystemType = namedConnection.SystemType
+	}
+	v.Check(namedConnection.Connection == nil || target.SystemType == namedConnection.SystemType, "target->system_type", fmt.Sprintf("must match the connection's system type, %v", namedConnection.SystemType))
+	target.OdbcDsn = namedConnection.OdbcDsn
+	target.Connection = namedConnection.Connection
+}
+
+func (r *ConnectionRegistry) seal(namedConnection NamedConnection) (storedConnection, error) {
+	secret := connectionSecret{OdbcDsn: namedConnection.OdbcDsn}
+	var connection *Connection
+	if namedConnection.Connection != nil {
+		secret.Password = namedConnection.Connection.Password
+		secret.Options = namedConnection.Connection.Options
+		connection = &Connection{
+			Host:     namedConnection.Connection.Host,
+			Port:     namedConnection.Connection.Port,
+			Database: namedConnection.Connection.Database,
+			User:     namedConnection.Connection.User,
+		}
+	}
+	plaintext, err := json.Marshal(secret)
+	if err != nil {
+		return storedConnection{}, fmt.Errorf("error encoding connection secret: %v", err)
+	}
+	nonce := make([]byte, r.aead.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return storedConnection{}, fmt.Errorf("error generating nonce: %v", err)
+	}
+	// the name is authenticated with the secret, so secrets can't be swapped
+	// between connections in the file
+	sealed := r.aead.Seal(nonce, nonce, plaintext, []byte(namedConnection.Name))
+	return storedConnection{
+		Name:       namedConnection.Name,
+		SystemType: namedConnection.SystemType,
+		Connection: connection,
+		Secret:     base64.StdEncoding.EncodeToString(sealed),
+		CreatedAt:  namedConnection.CreatedAt,
+	}, nil
+}
+
+func (r *ConnectionRegistry) open(stored storedConnection) (NamedConnection, error) {
+	sealed, err := base64.StdEncoding.DecodeString(stored.Secret)
+	if err != nil {
+		return NamedConnection{}, fmt.Errorf("error decoding connection secret: %v", err)
+	}
+	nonceSize := r.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return NamedConnection{}, errors.New("connection secret is too short")
+	}
+	plaintext, err := r.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(stored.Name))
+	if err != nil {
+		return NamedConnection{}, fmt.Errorf("error decrypting connection secret: %v", err)
+	}
+	secret := connectionSecret{}
+	err = json.Unmarshal(plaintext, &secret)
+	if err != nil {
+		return NamedConnection{}, fmt.Errorf("error decoding connection secret: %v", err)
+	}
+
+	namedConnection := NamedConnection{
+		Name:       stored.Name,
+		SystemType: stored.SystemType,
+		OdbcDsn:    secret.OdbcDsn,
+		CreatedAt:  stored.CreatedAt,
+	}
+	if stored.Connection != nil {
+		connection := *stored.Connection
+		connection.Password = secret.Password
+		connection.Options = secret.Options
+		namedConnection.Connection = &connection
+	}
+	return namedConnection, nil
+}
+
+// save writes the registry to a temporary file and renames it over the old
+// one, so a crash never leaves a half written file.
+func (r *ConnectionRegistry) save() error {
+	if r.path == "" {
+		return nil
+	}
+	storedConnections := []storedConnection{}
+	for _, stored := range r.connections {
+		storedConnections = append(storedConnections, stored)
+	}
+	sort.Slice(storedConnections, func(i, j int) bool {
+		return storedConnections[i].Name < storedConnections[j].Name
+	})
+	contents, err := json.MarshalIndent(storedConnections, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error encoding connections: %v", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating connections file: %v", err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(contents)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error writing connections file: %v", err)
+	}
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("error writing connections file: %v", err)
+	}
+	err = os.Rename(file.Name(), r.path)
+	if err != nil {
+		return fmt.Errorf("error saving connections file: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sqlpipe/sqlpipe/internal/validator"
+)
+
+// Connection describes a database by its parts, for callers that would rather
+// not write the DSN for each ODBC driver themselves. Options are added to the
+// DSN as they are, for driver specific settings such as TDS_Version.
+type Connection struct {
+	Host     string            `json:"host"`
+	Port     int               `json:"port"`
+	Database string            `json:"database"`
+	User     string            `json:"user"`
+	Password string            `json:"password"`
+	Options  map[string]string `json:"options"`
+}
+
+// dsnFormat is how one system type's ODBC driver expects a connection to be
+// described. Driver is the section name in the build/*.driver.template files.
+type dsnFormat struct {
+	driver      string
+	defaultPort int
+	required    []string
+	attributes  func(c Connection, port int) [][2]string
+}
+
+func serverAttributes(c Connection, port int) [][2]string {
+	return [][2]string{{"Server", c.Host}, {"Port", fmt.Sprint(port)}, {"Database", c.Database}, {"Uid", c.User}, {"Pwd", c.Password}}
+}
+
+func fileAttributes(c Connection, port int) [][2]string {
+	return [][2]string{{"Database", c.Database}}
+}
+
+var dsnFormats = map[string]dsnFormat{
+	"postgresql":  {driver: "PostgreSQL", defaultPort: 5432, required: []string{"host", "database", "user"}, attributes: serverAttributes},
+	"cockroachdb": {driver: "PostgreSQL", defaultPort: 26257, required: []string{"host", "database", "user"}, attributes: serverAttributes},
+	"redshift":    {driver: "PostgreSQL", defaultPort: 5439, required: []string{"host", "database", "user"}, attributes: serverAttributes},
+	"mssql":       {driver: "MSSQL", defaultPort: 1433, required: []string{"host", "user"}, attributes: serverAttributes},
+	"mysql":       {driver: "MySQL", defaultPort: 3306, required: []string{"host", "user"}, attributes: serverAttributes},
+	"mariadb":     {driver: "MySQL", defaultPort: 3306, required: []string{"host", "user"}, attributes: serverAttributes},
+	"snowflake": {driver: "Snowflake", required: []string{"host", "user"}, attributes: func(c Connection, port int) [][2]string {
+		return [][2]string{{"Server", c.Host}, {"Database", c.Database}, {"Uid", c.User}, {"Pwd", c.Password}}
+	}},
+	"oracle": {driver: "Oracle", defaultPort: 1521, required: []string{"host", "database", "user"}, attributes: func(c Connection, port int) [][2]string {
+		return [][2]string{{"DBQ", fmt.Sprintf("%v:%v/%v", c.Host, port, c.Database)}, {"Uid", c.User}, {"Pwd", c.Password}}
+	}},
+	"clickhouse": {driver: "ClickHouse", defaultPort: 8123, required: []string{"host"}, attributes: func(c Connection, port int) [][2]string {
+		return [][2]string{{"Url", fmt.Sprintf("http://%v:%v", c.Host, port)}, {"Database", c.Database}, {"Uid", c.User}, {"Pwd", c.Password}}
+	}},
+	"sqlite": {driver: "SQLite3", required: []string{"database"}, attributes: fileAttributes},
+	"duckdb": {driver: "DuckDB", required: []string{"database"}, attributes: fileAttributes},
+}
+
+// ConnectionSystemTypes returns the system types a connection can be built
+// for, in alphabetical order.
+func ConnectionSystemTypes() []string {
+	systemTypes := []string{}
+	for systemType := range dsnFormats {
+		systemTypes = append(systemTypes, systemType)
+	}
+	sort.Strings(systemTypes)
+	return systemTypes
+}
+
+// DriverSystemTypes returns the system types whose connections use the ODBC
+// driver installed under the name driver, in alphabetical order.
+func DriverSystemTypes(driver string) []string {
+	systemTypes := []string{}
+	for _, systemType := range ConnectionSystemTypes() {
+		if strings.EqualFold(dsnFormats[systemType].driver, strings.TrimSpace(driver)) {
+			systemTypes = append(systemTypes, systemType)
+		}
+	}
+	return systemTypes
+}
+
+// Dsn writes the connection as an ODBC connection string for the system
+// type's driver. Empty attributes are left out.
+func (c Connection) Dsn(systemType string) string {
+	format := dsnFormats[systemType]
+	port := c.Port
+	if port == 0 {
+		port = format.defaultPort
+	}
+
+	var dsn strings.Builder
+	writeDsnAttribute(&dsn, "Driver", format.driver)
+	for _, attribute := range format.attributes(c, port) {
+		if attribute[1] != "" {
+			writeDsnAttribute(&dsn, attribute[0], attribute[1])
+		}
+	}
+	keys := []string{}
+	for key := range c.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeDsnAttribute(&dsn, key, c.Options[key])
+	}
+	return dsn.String()
+}
+
+// writeDsnAttribute braces values that would otherwise end the attribute
+// early, doubling any closing brace inside them.
+func writeDsnAttribute(dsn *strings.Builder, key, value string) {
+	if strings.ContainsAny(value, ";{}=") || strings.TrimSpace(value) != value {
+		value = "{" + strings.ReplaceAll(value, "}", "}}") + "}"
+	}
+	fmt.Fprintf(dsn, "%v=%v;", key, value)
+}
+
+func ValidateConnection(v *validator.Validator, key, systemType string, connection Connection) {
+	format, ok := dsnFormats[systemType]
+	if !ok {
+		v.AddError(key, fmt.Sprintf("is not supported for system type %v, must be one of %v", systemType, strings.Join(ConnectionSystemTypes(), ", ")))
+		return
+	}
+	fields := map[string]string{
+		"host":     connection.Host,
+		"database": connection.Database,
+		"user":     connection.User,
+	}
+	for _, field := range format.required {
+		v.Check(fields[field] != "", fmt.Sprintf("%v->%v", key, field), "must be provided")
+	}
+	v.Check(connection.Port >= 0 && connection.Port <= 65535, key+"->port", "must be between 1 and 65535, or 0 for the default port")
+
+	reserved := map[string]bool{"driver": true, "dsn": true}
+	for _, attribute := range format.attributes(connection, format.defaultPort) {
+		reserved[strings.ToLower(attribute[0])] = true
+	}
+	for option := range connection.Options {
+		v.Check(option != "" && !strings.ContainsAny(option, ";={}"), key+"->options", fmt.Sprintf("must not have the key %q", option))
+		v.Check(!reserved[strings.ToLower(option)], key+"->options", fmt.Sprintf("must not set %v, which the connection sets", option))
+	}
+}
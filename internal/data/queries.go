@@ -7,9 +7,11 @@ import (
 type Query struct {
 	Source Source `json:"source"`
 	Query  string `json:"query"`
+	Timeouts
 }
 
 func ValidateQuery(v *validator.Validator, query *Query) {
 	ValidateSource(v, query.Source)
 	v.Check(query.Query != "", "query", "must be provided")
+	ValidateTimeouts(v, query.Timeouts)
 }
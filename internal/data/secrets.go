@@ -0,0 +1,144 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sqlpipe/sqlpipe/internal/validator"
+)
+
+var secretReferenceRX = regexp.MustCompile(`\$\{(env|file):([^}]*)\}`)
+
+// SecretResolver replaces ${env:NAME} and ${file:/path} references in request
+// fields with the environment variable or file contents they name. Only
+// variables starting with one of EnvPrefixes and files inside one of Dirs can
+// be referenced, so callers can't read anything else on the server.
+type SecretResolver struct {
+	EnvPrefixes []string
+	Dirs        []string
+}
+
+// Resolve returns s with its secret references replaced.
+func (sr *SecretResolver) Resolve(s string) (string, error) {
+	var resolveErr error
+	resolved := secretReferenceRX.ReplaceAllStringFunc(s, func(reference string) string {
+		if resolveErr != nil {
+			return ""
+		}
+		match := secretReferenceRX.FindStringSubmatch(reference)
+		var value string
+		if match[1] == "env" {
+			value, resolveErr = sr.env(match[2])
+		} else {
+			value, resolveErr = sr.file(match[2])
+		}
+		return value
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return resolved, nil
+}
+
+func (sr *SecretResolver) env(name string) (string, error) {
+	allowed := false
+	for _, prefix := range sr.EnvPrefixes {
+		if prefix != "" && strings.HasPrefix(name, prefix) {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("references environment variable %v, which is not allowed", name)
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("references environment variable %v, which is not set", name)
+	}
+	return value, nil
+}
+
+func (sr *SecretResolver) file(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("references file %v, which is not an absolute path", path)
+	}
+	// symlinks are followed first, so a link inside an allowed directory can't
+	// point outside of it
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("references file %v, which is not allowed or does not exist", path)
+	}
+	allowed := false
+	for _, dir := range sr.Dirs {
+		resolvedDir, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(resolvedDir, resolvedPath)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("references file %v, which is not allowed or does not exist", path)
+	}
+	contents, err := os.ReadFile(resolvedPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading file %v: %v", path, err)
+	}
+	// secret files usually end in a newline that isn't part of the secret
+	return strings.TrimRight(string(contents), "\r\n"), nil
+}
+
+// ResolveField replaces the secret references in *field, adding a validation
+// error under key if one can't be resolved.
+func (sr *SecretResolver) ResolveField(v *validator.Validator, key string, field *string) {
+	resolved, err := sr.Resolve(*field)
+	if err != nil {
+		v.AddError(key, err.Error())
+		return
+	}
+	*field = resolved
+}
+
+// ResolveSource replaces the secret references in the source's DSN and
+// connection password and options.
+func (sr *SecretResolver) ResolveSource(v *validator.Validator, source *Source) {
+	sr.ResolveField(v, "source->odbc_dsn", &source.OdbcDsn)
+	if source.Connection != nil {
+		source.Connection = sr.resolveConnection(v, "source->connection", *source.Connection)
+	}
+}
+
+// ResolveTarget replaces the secret references in the target's DSN and
+// connection password and options.
+func (sr *SecretResolver) ResolveTarget(v *validator.Validator, target *Target) {
+	sr.ResolveField(v, "target->odbc_dsn", &target.OdbcDsn)
+	if target.Connection != nil {
+		target.Connection = sr.resolveConnection(v, "target->connection", *target.Connection)
+	}
+}
+
+// resolveConnection returns a copy of the connection with its secrets
+// resolved, leaving the original, which may be a saved connection, as is.
+func (sr *SecretResolver) resolveConnection(v *validator.Validator, key string, connection Connection) *Connection {
+	sr.ResolveField(v, key+"->password", &connection.Password)
+	if connection.Options != nil {
+		options := make(map[string]string, len(connection.Options))
+		for option, value := range connection.Options {
+			sr.ResolveField(v, key+"->options->"+option, &value)
+			options[option] = value
+		}
+		connection.Options = options
+	}
+	return &connection
+}
+
+// ResolveS3Upload replaces the secret references in the AWS credentials.
+func (sr *SecretResolver) ResolveS3Upload(v *validator.Validator, s3Upload *S3Upload) {
+	sr.ResolveField(v, "aws_key", &s3Upload.AwsKey)
+	sr.ResolveField(v, "aws_secret", &s3Upload.AwsSecret)
+	sr.ResolveField(v, "aws_token", &s3Upload.AwsToken)
+}
@@ -7,10 +7,30 @@ import (
 )
 
 type Source struct {
-	OdbcDsn string  `json:"odbc_dsn"`
-	Db      *sql.DB `json:"-"`
+	OdbcDsn string `json:"odbc_dsn"`
+	// Connection and SystemType describe the source instead of OdbcDsn
+	Connection *Connection `json:"connection"`
+	SystemType string      `json:"system_type"`
+	// ConnectionName refers to a connection saved with /v2/connections
+	ConnectionName string  `json:"connection_name"`
+	Db             *sql.DB `json:"-"`
+}
+
+// Dsn returns the ODBC connection string for the source.
+func (s Source) Dsn() string {
+	if s.Connection != nil {
+		return s.Connection.Dsn(s.SystemType)
+	}
+	return s.OdbcDsn
 }
 
 func ValidateSource(v *validator.Validator, source Source) {
-	v.Check(source.OdbcDsn != "", "source->odbc_dsn", "must be provided")
+	v.Check(source.OdbcDsn != "" || source.Connection != nil, "source->odbc_dsn", "must be provided, or source->connection instead")
+	v.Check(source.OdbcDsn == "" || source.Connection == nil, "source->connection", "must not be provided with source->odbc_dsn")
+	if source.Connection != nil {
+		v.Check(source.SystemType != "", "source->system_type", "must be provided with source->connection")
+		if source.SystemType != "" {
+			ValidateConnection(v, "source->connection", source.SystemType, *source.Connection)
+		}
+	}
 }
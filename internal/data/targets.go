@@ -2,20 +2,58 @@ package data
 
 import (
 	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/sqlpipe/sqlpipe/internal/validator"
 )
 
+// clickhouseEngineRX matches an engine name with optional arguments, such as
+// ReplacingMergeTree(version), but nothing after them.
+var clickhouseEngineRX = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\([^();]*\))?$`)
+
 type Target struct {
-	SystemType string  `json:"system_type"`
-	OdbcDsn    string  `json:"odbc_dsn"`
-	Schema     string  `json:"schema"`
-	Table      string  `json:"table"`
-	Db         *sql.DB `json:"-"`
+	SystemType string      `json:"system_type"`
+	OdbcDsn    string      `json:"odbc_dsn"`
+	Connection *Connection `json:"connection"`
+	// ConnectionName refers to a connection saved with /v2/connections
+	ConnectionName string   `json:"connection_name"`
+	Schema         string   `json:"schema"`
+	Table          string   `json:"table"`
+	Engine         string   `json:"engine"`
+	OrderBy        []string `json:"order_by"`
+	Db             *sql.DB  `json:"-"`
 }
 
-func ValidateTarget(v *validator.Validator, target Target) {
-	v.Check(target.SystemType != "", "target->system_type", "must be provided")
-	v.Check(target.OdbcDsn != "", "target->odbc_dsn", "must be provided")
+// Dsn returns the ODBC connection string for the target.
+func (t Target) Dsn() string {
+	if t.Connection != nil {
+		return t.Connection.Dsn(t.SystemType)
+	}
+	return t.OdbcDsn
+}
+
+// ValidateTarget checks a target, whose system type must be one of
+// systemTypes, the types there are dialects for.
+func ValidateTarget(v *validator.Validator, target Target, systemTypes []string) {
+	validateTargetSettings(v, target, systemTypes)
 	v.Check(target.Table != "", "target->table", "must be provided")
 }
+
+// validateTargetSettings checks everything but the table, which schema
+// transfers name after each source table.
+func validateTargetSettings(v *validator.Validator, target Target, systemTypes []string) {
+	v.Check(target.SystemType != "", "target->system_type", "must be provided")
+	if target.SystemType != "" {
+		v.Check(validator.PermittedValue(target.SystemType, systemTypes...), "target->system_type", fmt.Sprintf("must be one of %v", strings.Join(systemTypes, ", ")))
+	}
+	v.Check(target.OdbcDsn != "" || target.Connection != nil, "target->odbc_dsn", "must be provided, or target->connection instead")
+	v.Check(target.OdbcDsn == "" || target.Connection == nil, "target->connection", "must not be provided with target->odbc_dsn")
+	if target.Connection != nil && target.SystemType != "" {
+		ValidateConnection(v, "target->connection", target.SystemType, *target.Connection)
+	}
+	v.Check(target.Engine == "" || target.SystemType == "clickhouse", "target->engine", "is only supported for clickhouse targets")
+	v.Check(target.Engine == "" || validator.Matches(target.Engine, clickhouseEngineRX), "target->engine", "must be an engine name, optionally followed by its arguments in parentheses")
+	v.Check(len(target.OrderBy) == 0 || target.SystemType == "clickhouse", "target->order_by", "is only supported for clickhouse targets")
+}
@@ -0,0 +1,18 @@
+package data
+
+import (
+	"github.com/sqlpipe/sqlpipe/internal/validator"
+)
+
+// Timeouts limit a request, in seconds, with 0 meaning no limit.
+// QueryTimeout is how long each statement may take to execute, not counting
+// reading its rows, and TotalTimeout is how long the whole request may take.
+type Timeouts struct {
+	QueryTimeout int `json:"query_timeout"`
+	TotalTimeout int `json:"total_timeout"`
+}
+
+func ValidateTimeouts(v *validator.Validator, timeouts Timeouts) {
+	v.Check(timeouts.QueryTimeout >= 0, "query_timeout", "must not be negative")
+	v.Check(timeouts.TotalTimeout >= 0, "total_timeout", "must not be negative")
+}
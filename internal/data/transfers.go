@@ -1,19 +1,125 @@
 package data
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/sqlpipe/sqlpipe/internal/validator"
 )
 
 type Transfer struct {
-	Source            Source `json:"source"`
-	Target            Target `json:"target"`
-	Query             string `json:"query"`
-	DropTargetTable   bool   `json:"drop_target_table"`
-	CreateTargetTable bool   `json:"create_target_table"`
+	Source Source `json:"source"`
+	Target Target `json:"target"`
+	Query  string `json:"query"`
+	// SourceTable names the data to transfer instead of Query
+	SourceTable       *SourceTable `json:"source_table"`
+	DropTargetTable   bool         `json:"drop_target_table"`
+	CreateTargetTable bool         `json:"create_target_table"`
+	// TypeMappings overrides how source column types are created in the
+	// target, as DDL templates keyed by source type
+	TypeMappings map[string]string `json:"type_mappings"`
+	// InsertMethod is how rows are written to the target, either as values
+	// in insert statements, the default, or bound as parameter arrays
+	InsertMethod string `json:"insert_method"`
+	Timeouts
+}
+
+// InsertMethods are the ways of writing rows to a target.
+var InsertMethods = []string{"values", "parameters"}
+
+func validateInsertMethod(v *validator.Validator, insertMethod string) {
+	v.Check(insertMethod == "" || validator.PermittedValue(insertMethod, InsertMethods...), "insert_method", fmt.Sprintf("must be one of %v", strings.Join(InsertMethods, ", ")))
 }
 
-func ValidateTransfer(v *validator.Validator, transfer *Transfer) {
+func ValidateTransfer(v *validator.Validator, transfer *Transfer, targetSystemTypes []string) {
 	ValidateSource(v, transfer.Source)
-	ValidateTarget(v, transfer.Target)
-	v.Check(transfer.Query != "", "query", "must be provided")
+	ValidateTarget(v, transfer.Target, targetSystemTypes)
+	ValidateTimeouts(v, transfer.Timeouts)
+	validateInsertMethod(v, transfer.InsertMethod)
+	v.Check(transfer.Query != "" || transfer.SourceTable != nil, "query", "must be provided, or source_table instead")
+	v.Check(transfer.Query == "" || transfer.SourceTable == nil, "source_table", "must not be provided with query")
+	if transfer.SourceTable != nil {
+		ValidateSourceTable(v, *transfer.SourceTable)
+	}
+}
+
+// SchemaTransfer copies every table of a source schema that matches Include
+// and not Exclude into the target schema, keeping the table names.
+type SchemaTransfer struct {
+	Source       Source `json:"source"`
+	Target       Target `json:"target"`
+	SourceSchema string `json:"source_schema"`
+	// Include and Exclude are ODBC search patterns, where % matches any run of
+	// characters and _ matches one. No Include means every table.
+	Include            []string `json:"include"`
+	Exclude            []string `json:"exclude"`
+	DropTargetTables   bool     `json:"drop_target_tables"`
+	CreateTargetTables bool     `json:"create_target_tables"`
+	// CreateForeignKeys adds the source's foreign keys between the transferred
+	// tables once they are loaded
+	CreateForeignKeys bool              `json:"create_foreign_keys"`
+	TypeMappings      map[string]string `json:"type_mappings"`
+	// Concurrency is how many tables transfer at once
+	Concurrency     int    `json:"concurrency"`
+	ContinueOnError bool   `json:"continue_on_error"`
+	InsertMethod    string `json:"insert_method"`
+	Timeouts
+}
+
+func ValidateSchemaTransfer(v *validator.Validator, schemaTransfer *SchemaTransfer, targetSystemTypes []string) {
+	ValidateSource(v, schemaTransfer.Source)
+	validateTargetSettings(v, schemaTransfer.Target, targetSystemTypes)
+	ValidateTimeouts(v, schemaTransfer.Timeouts)
+	validateInsertMethod(v, schemaTransfer.InsertMethod)
+	v.Check(schemaTransfer.SourceSchema != "", "source_schema", "must be provided")
+	v.Check(schemaTransfer.Target.Table == "", "target->table", "must not be provided, tables keep their source names")
+	v.Check(!schemaTransfer.CreateForeignKeys || schemaTransfer.CreateTargetTables, "create_foreign_keys", "requires create_target_tables")
+	v.Check(schemaTransfer.Concurrency >= 0, "concurrency", "must not be negative")
+	v.Check(schemaTransfer.Concurrency <= 32, "concurrency", "must not be more than 32")
+}
+
+// SourceTable selects columns of a table, or all of them if there are none,
+// from the rows matching every condition of Where.
+type SourceTable struct {
+	Schema  string      `json:"schema"`
+	Table   string      `json:"table"`
+	Columns []string    `json:"columns"`
+	Where   []Condition `json:"where"`
+}
+
+// Condition compares a column to Value, which is a list for the in operator
+// and left out for is null and is not null.
+type Condition struct {
+	Column   string `json:"column"`
+	Operator string `json:"operator"`
+	Value    any    `json:"value"`
+}
+
+var ConditionOperators = []string{"=", "!=", "<>", "<", "<=", ">", ">=", "like", "not like", "in", "not in", "is null", "is not null"}
+
+func ValidateSourceTable(v *validator.Validator, sourceTable SourceTable) {
+	v.Check(sourceTable.Table != "", "source_table->table", "must be provided")
+	for _, column := range sourceTable.Columns {
+		v.Check(column != "", "source_table->columns", "must not contain empty names")
+	}
+	v.Check(validator.Unique(sourceTable.Columns), "source_table->columns", "must not contain duplicates")
+	for i, condition := range sourceTable.Where {
+		key := fmt.Sprintf("source_table->where[%v]", i)
+		v.Check(condition.Column != "", key+"->column", "must be provided")
+		if !validator.PermittedValue(condition.Operator, ConditionOperators...) {
+			v.AddError(key+"->operator", fmt.Sprintf("must be one of %v", strings.Join(ConditionOperators, ", ")))
+			continue
+		}
+		switch condition.Operator {
+		case "is null", "is not null":
+			v.Check(condition.Value == nil, key+"->value", "must not be provided for "+condition.Operator)
+		case "in", "not in":
+			values, ok := condition.Value.([]any)
+			v.Check(ok && len(values) > 0, key+"->value", "must be a non-empty list for "+condition.Operator)
+		default:
+			_, isList := condition.Value.([]any)
+			_, isObject := condition.Value.(map[string]any)
+			v.Check(condition.Value != nil && !isList && !isObject, key+"->value", "must be a string, number or boolean")
+		}
+	}
 }
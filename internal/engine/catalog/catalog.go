@@ -0,0 +1,355 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sqlpipe/odbc"
+	"github.com/sqlpipe/odbc/api"
+	"github.com/sqlpipe/sqlpipe/internal/data"
+)
+
+type Schema struct {
+	Catalog string `json:"catalog,omitempty"`
+	Name    string `json:"name"`
+}
+
+type Table struct {
+	Catalog string `json:"catalog,omitempty"`
+	Schema  string `json:"schema,omitempty"`
+	Name    string `json:"name"`
+	Type    string `json:"type,omitempty"`
+}
+
+type Column struct {
+	Catalog       string `json:"catalog,omitempty"`
+	Schema        string `json:"schema,omitempty"`
+	Table         string `json:"table"`
+	Name          string `json:"name"`
+	Position      int64  `json:"position"`
+	TypeName      string `json:"type_name"`
+	OdbcType      int64  `json:"odbc_type"`
+	Size          int64  `json:"size"`
+	DecimalDigits int64  `json:"decimal_digits"`
+	Nullable      bool   `json:"nullable"`
+	Default       string `json:"default,omitempty"`
+	// PrimaryKeyPosition is the column's place in the table's primary key,
+	// or 0 if it isn't part of it
+	PrimaryKeyPosition int64 `json:"primary_key_position,omitempty"`
+}
+
+// ListSchemas returns the schemas holding tables that match the search.
+func ListSchemas(ctx context.Context, catalogSearch data.CatalogSearch) ([]Schema, error) {
+	tables, err := ListTables(ctx, catalogSearch)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[Schema]bool{}
+	schemas := []Schema{}
+	for _, table := range tables {
+		schema := Schema{Catalog: table.Catalog, Name: table.Schema}
+		if seen[schema] {
+			continue
+		}
+		seen[schema] = true
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		if schemas[i].Catalog != schemas[j].Catalog {
+			return schemas[i].Catalog < schemas[j].Catalog
+		}
+		return schemas[i].Name < schemas[j].Name
+	})
+	return schemas, nil
+}
+
+// ListTables returns the tables and views that match the search.
+func ListTables(ctx context.Context, catalogSearch data.CatalogSearch) ([]Table, error) {
+	tables := []Table{}
+	err := WithOdbcConn(ctx, catalogSearch.Source.Db, func(conn *odbc.Conn) error {
+		rows, err := conn.Tables(catalogSearch.Catalog, catalogSearch.Schema, catalogSearch.Table, "")
+		if err != nil {
+			return fmt.Errorf("error listing tables: %w", err)
+		}
+		return readCatalogRows(rows, func(vals []driver.Value) {
+			tables = append(tables, Table{
+				Catalog: catalogString(vals, 0),
+				Schema:  catalogString(vals, 1),
+				Name:    catalogString(vals, 2),
+				Type:    catalogString(vals, 3),
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// ListColumns returns the columns of the tables that match the search, with
+// their positions in their table's primary key.
+func ListColumns(ctx context.Context, catalogSearch data.CatalogSearch) ([]Column, error) {
+	columns := []Column{}
+	err := WithOdbcConn(ctx, catalogSearch.Source.Db, func(conn *odbc.Conn) error {
+		rows, err := conn.Columns(catalogSearch.Catalog, catalogSearch.Schema, catalogSearch.Table, "")
+		if err != nil {
+			return fmt.Errorf("error listing columns: %w", err)
+		}
+		err = readCatalogRows(rows, func(vals []driver.Value) {
+			columns = append(columns, Column{
+				Catalog:       catalogString(vals, 0),
+				Schema:        catalogString(vals, 1),
+				Table:         catalogString(vals, 2),
+				Name:          catalogString(vals, 3),
+				OdbcType:      catalogInt(vals, 4),
+				TypeName:      catalogString(vals, 5),
+				Size:          catalogInt(vals, 6),
+				DecimalDigits: catalogInt(vals, 8),
+				Nullable:      catalogInt(vals, 10) != 0,
+				Default:       catalogString(vals, 12),
+				Position:      catalogInt(vals, 16),
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		// primary keys can only be asked for one table at a time, and only
+		// once the columns result set is closed
+		primaryKeys := map[Table]map[string]int64{}
+		for _, column := range columns {
+			table := Table{Catalog: column.Catalog, Schema: column.Schema, Name: column.Table}
+			if _, ok := primaryKeys[table]; ok {
+				continue
+			}
+			primaryKeys[table] = map[string]int64{}
+			rows, err := conn.PrimaryKeys(table.Catalog, table.Schema, table.Name)
+			if err != nil {
+				return fmt.Errorf("error listing primary keys of %v: %w", table.Name, err)
+			}
+			err = readCatalogRows(rows, func(vals []driver.Value) {
+				primaryKeys[table][catalogString(vals, 3)] = catalogInt(vals, 4)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for i, column := range columns {
+			table := Table{Catalog: column.Catalog, Schema: column.Schema, Name: column.Table}
+			columns[i].PrimaryKeyPosition = primaryKeys[table][column.Name]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// ForeignKey is a foreign key from Columns of Table to ReferencedColumns of
+// ReferencedTable.
+type ForeignKey struct {
+	Name              string   `json:"name"`
+	Table             Table    `json:"table"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   Table    `json:"referenced_table"`
+	ReferencedColumns []string `json:"referenced_columns"`
+}
+
+// ListForeignKeys returns the foreign keys the tables have.
+func ListForeignKeys(ctx context.Context, db *sql.DB, tables []Table) ([]ForeignKey, error) {
+	foreignKeys := []ForeignKey{}
+	err := WithOdbcConn(ctx, db, func(conn *odbc.Conn) error {
+		for _, table := range tables {
+			rows, err := conn.ForeignKeys("", "", "", table.Catalog, table.Schema, table.Name)
+			if err != nil {
+				return fmt.Errorf("error listing foreign keys of %v: %w", table.Name, err)
+			}
+			// rows are ordered by referenced table and KEY_SEQ, so the columns
+			// of keys to the same table interleave. Rows all have this table as
+			// their FK table, so they're grouped by FK_NAME, or by KEY_SEQ
+			// restarting when the driver doesn't name keys.
+			keys := map[string]int{}
+			err = readCatalogRows(rows, func(vals []driver.Value) {
+				name := catalogString(vals, 11)
+				index, ok := keys[name]
+				if !ok || name == "" && catalogInt(vals, 8) <= 1 {
+					index = len(foreignKeys)
+					keys[name] = index
+					foreignKeys = append(foreignKeys, ForeignKey{
+						Name:  name,
+						Table: Table{Catalog: catalogString(vals, 4), Schema: catalogString(vals, 5), Name: catalogString(vals, 6)},
+						ReferencedTable: Table{
+							Catalog: catalogString(vals, 0),
+							Schema:  catalogString(vals, 1),
+							Name:    catalogString(vals, 2),
+						},
+					})
+				}
+				foreignKey := &foreignKeys[index]
+				foreignKey.Columns = append(foreignKey.Columns, catalogString(vals, 7))
+				foreignKey.ReferencedColumns = append(foreignKey.ReferencedColumns, catalogString(vals, 3))
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return foreignKeys, nil
+}
+
+// IdentifierQuote returns the character the database quotes identifiers with,
+// or "" if it doesn't support quoted identifiers.
+func IdentifierQuote(ctx context.Context, db *sql.DB) (string, error) {
+	var quote string
+	err := WithOdbcConn(ctx, db, func(conn *odbc.Conn) error {
+		var err error
+		quote, err = conn.GetInfoString(api.SQL_IDENTIFIER_QUOTE_CHAR)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting identifier quote character: %w", err)
+	}
+	return strings.TrimSpace(quote), nil
+}
+
+// SearchPatternEscape returns the string that escapes _ and % in the
+// database's catalog search patterns, or "" if they can't be escaped.
+func SearchPatternEscape(ctx context.Context, db *sql.DB) (string, error) {
+	var escape string
+	err := WithOdbcConn(ctx, db, func(conn *odbc.Conn) error {
+		var err error
+		escape, err = conn.GetInfoString(api.SQL_SEARCH_PATTERN_ESCAPE)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting search pattern escape: %w", err)
+	}
+	return strings.TrimSpace(escape), nil
+}
+
+// EscapeSearchPattern escapes the wildcards in name with escape, so a search
+// pattern only matches name itself.
+func EscapeSearchPattern(name, escape string) string {
+	if escape == "" {
+		return name
+	}
+	return strings.NewReplacer(escape, escape+escape, "_", escape+"_", "%", escape+"%").Replace(name)
+}
+
+// QuotedName returns the table's schema qualified name, quoted with quote.
+func (t Table) QuotedName(quote string) string {
+	name := QuoteIdentifier(t.Name, quote)
+	if t.Schema != "" {
+		name = QuoteIdentifier(t.Schema, quote) + "." + name
+	}
+	return name
+}
+
+// QuoteIdentifier quotes an identifier with the character IdentifierQuote
+// returned, doubling any it contains. Either bracket quotes with the pair
+// [ and ], doubling any ] the identifier contains.
+func QuoteIdentifier(identifier, quote string) string {
+	switch quote {
+	case "":
+		return identifier
+	case "[", "]":
+		return "[" + strings.ReplaceAll(identifier, "]", "]]") + "]"
+	}
+	return quote + strings.ReplaceAll(identifier, quote, quote+quote) + quote
+}
+
+// MatchPattern reports whether name matches an ODBC search pattern, where %
+// matches any run of characters and _ matches one.
+func MatchPattern(pattern, name string) bool {
+	var expression strings.Builder
+	expression.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			expression.WriteString(".*")
+		case '_':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.MustCompile(expression.String()).MatchString(name)
+}
+
+// WithOdbcConn runs f with the driver connection behind one of db's
+// connections, for driver features database/sql doesn't expose.
+func WithOdbcConn(ctx context.Context, db *sql.DB, f func(conn *odbc.Conn) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting connection: %w", err)
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		odbcConn, ok := driverConn.(*odbc.Conn)
+		if !ok {
+			return errors.New("connection is not an odbc connection")
+		}
+		return f(odbcConn)
+	})
+}
+
+func readCatalogRows(rows driver.Rows, f func(vals []driver.Value)) error {
+	defer rows.Close()
+	vals := make([]driver.Value, len(rows.Columns()))
+	for {
+		err := rows.Next(vals)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading catalog rows: %w", err)
+		}
+		f(vals)
+	}
+}
+
+// catalogString returns a text column of a catalog result set, or "" if the
+// driver left it null or doesn't return that many columns.
+func catalogString(vals []driver.Value, i int) string {
+	if i >= len(vals) {
+		return ""
+	}
+	switch v := vals[i].(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
+
+// catalogInt returns a numeric column of a catalog result set, or 0 if the
+// driver left it null or doesn't return that many columns.
+func catalogInt(vals []driver.Value, i int) int64 {
+	if i >= len(vals) {
+		return 0
+	}
+	switch v := vals[i].(type) {
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
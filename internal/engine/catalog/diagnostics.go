@@ -0,0 +1,119 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/sqlpipe/odbc"
+	"github.com/sqlpipe/odbc/api"
+)
+
+// Diagnostics is what the driver manager and driver report about a
+// connection.
+type Diagnostics struct {
+	DriverName        string `json:"driver_name"`
+	DriverVersion     string `json:"driver_version"`
+	DriverOdbcVersion string `json:"driver_odbc_version"`
+	DbmsName          string `json:"dbms_name"`
+	DbmsVersion       string `json:"dbms_version"`
+	Database          string `json:"database"`
+	User              string `json:"user"`
+	// Schema is only reported for system types with a query for it, as ODBC
+	// has no way to ask
+	Schema string `json:"schema,omitempty"`
+	// SchemaError is why the current schema couldn't be found, which doesn't
+	// fail the rest of the diagnostics
+	SchemaError *DiagnosticError `json:"schema_error,omitempty"`
+}
+
+var currentSchemaQueries = map[string]string{
+	"postgresql":  "select current_schema()",
+	"cockroachdb": "select current_schema()",
+	"redshift":    "select current_schema()",
+	"snowflake":   "select current_schema()",
+	"duckdb":      "select current_schema()",
+	"mssql":       "select schema_name()",
+	"mysql":       "select database()",
+	"mariadb":     "select database()",
+	"clickhouse":  "select currentDatabase()",
+	"oracle":      "select sys_context('USERENV', 'CURRENT_SCHEMA') from dual",
+}
+
+// Diagnose asks the driver about the connection with SQLGetInfo. Drivers that
+// don't answer an info type leave it empty.
+func Diagnose(ctx context.Context, db *sql.DB, systemType string) (Diagnostics, error) {
+	diagnostics := Diagnostics{}
+	err := WithOdbcConn(ctx, db, func(conn *odbc.Conn) error {
+		for infoType, value := range map[api.SQLUSMALLINT]*string{
+			api.SQL_DRIVER_NAME:     &diagnostics.DriverName,
+			api.SQL_DRIVER_VER:      &diagnostics.DriverVersion,
+			api.SQL_DRIVER_ODBC_VER: &diagnostics.DriverOdbcVersion,
+			api.SQL_DBMS_NAME:       &diagnostics.DbmsName,
+			api.SQL_DBMS_VER:        &diagnostics.DbmsVersion,
+			api.SQL_DATABASE_NAME:   &diagnostics.Database,
+			api.SQL_USER_NAME:       &diagnostics.User,
+		} {
+			*value, _ = conn.GetInfoString(infoType)
+		}
+		return nil
+	})
+	if err != nil {
+		return diagnostics, err
+	}
+
+	query, ok := currentSchemaQueries[systemType]
+	if ok {
+		var schema sql.NullString
+		err = db.QueryRowContext(ctx, query).Scan(&schema)
+		if err != nil {
+			schemaError := NewDiagnosticError(err)
+			diagnostics.SchemaError = &schemaError
+		}
+		diagnostics.Schema = schema.String
+	}
+	return diagnostics, nil
+}
+
+// Latency times a trivial query, which on a connection that is already open
+// is one round trip to the database.
+func Latency(ctx context.Context, db *sql.DB, systemType string) (time.Duration, error) {
+	query := "select 1"
+	if systemType == "oracle" {
+		query = "select 1 from dual"
+	}
+	var one int
+	start := time.Now()
+	err := db.QueryRowContext(ctx, query).Scan(&one)
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// DiagnosticError is an error normalized to its first ODBC diagnostic record.
+type DiagnosticError struct {
+	SqlState    string `json:"sqlstate,omitempty"`
+	NativeError int    `json:"native_error,omitempty"`
+	Function    string `json:"function,omitempty"`
+	Message     string `json:"message"`
+}
+
+func NewDiagnosticError(err error) DiagnosticError {
+	var odbcErr *odbc.Error
+	switch {
+	case errors.As(err, &odbcErr) && len(odbcErr.Diag) > 0:
+		return DiagnosticError{
+			SqlState:    odbcErr.Diag[0].State,
+			NativeError: odbcErr.Diag[0].NativeError,
+			Function:    odbcErr.APIName,
+			Message:     odbcErr.Diag[0].Message,
+		}
+	case errors.Is(err, driver.ErrBadConn):
+		// the driver turns communication link failures into ErrBadConn
+		return DiagnosticError{SqlState: "08S01", Message: "communication link failure"}
+	}
+	return DiagnosticError{Message: err.Error()}
+}
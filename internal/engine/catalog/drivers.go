@@ -0,0 +1,64 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sqlpipe/odbc"
+	"github.com/sqlpipe/sqlpipe/internal/data"
+)
+
+// Driver is an ODBC driver installed on the server.
+type Driver struct {
+	Name       string            `json:"name"`
+	Attributes map[string]string `json:"attributes"`
+	// SystemTypes are the system types whose connections use the driver
+	SystemTypes []string `json:"system_types"`
+}
+
+// DataSource is a system DSN defined on the server, which odbc_dsn can refer
+// to with DSN=name.
+type DataSource struct {
+	Name        string   `json:"name"`
+	Driver      string   `json:"driver"`
+	SystemTypes []string `json:"system_types"`
+}
+
+// ListDrivers returns the drivers and system DSNs the driver manager knows
+// about, ordered by name.
+func ListDrivers() ([]Driver, []DataSource, error) {
+	odbcDrivers, err := odbc.Drivers()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing drivers: %v", err)
+	}
+	drivers := []Driver{}
+	for _, odbcDriver := range odbcDrivers {
+		drivers = append(drivers, Driver{
+			Name:        odbcDriver.Description,
+			Attributes:  odbcDriver.Attributes,
+			SystemTypes: data.DriverSystemTypes(odbcDriver.Description),
+		})
+	}
+	sort.Slice(drivers, func(i, j int) bool {
+		return drivers[i].Name < drivers[j].Name
+	})
+
+	odbcDataSources, err := odbc.DataSources()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing data sources: %v", err)
+	}
+	dataSources := []DataSource{}
+	for _, odbcDataSource := range odbcDataSources {
+		// the driver manager describes a DSN with its driver's name
+		dataSources = append(dataSources, DataSource{
+			Name:        odbcDataSource.Name,
+			Driver:      odbcDataSource.Description,
+			SystemTypes: data.DriverSystemTypes(odbcDataSource.Description),
+		})
+	}
+	sort.Slice(dataSources, func(i, j int) bool {
+		return dataSources[i].Name < dataSources[j].Name
+	})
+
+	return drivers, dataSources, nil
+}
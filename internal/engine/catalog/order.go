@@ -0,0 +1,136 @@
+package catalog
+
+import (
+	"sort"
+)
+
+// LoadOrder groups tables into levels that can be loaded one after another,
+// so each table's level comes after the levels of the tables its foreign keys
+// refer to. Tables in a foreign key cycle share a level, and each cycle is
+// also returned so it can be reported. Foreign keys to tables outside the list
+// are ignored.
+func LoadOrder(tables []Table, foreignKeys []ForeignKey) (levels [][]Table, cycles [][]Table) {
+	sorted := append([]Table{}, tables...)
+	sortTables(sorted)
+	index := map[Table]int{}
+	for i, table := range sorted {
+		index[table.key()] = i
+	}
+
+	references := make([][]int, len(sorted))
+	for _, foreignKey := range foreignKeys {
+		from, ok := index[foreignKey.Table.key()]
+		if !ok {
+			continue
+		}
+		to, ok := index[foreignKey.ReferencedTable.key()]
+		if !ok || to == from {
+			continue
+		}
+		references[from] = append(references[from], to)
+	}
+
+	components := stronglyConnectedComponents(references)
+	componentOf := make([]int, len(sorted))
+	for c, component := range components {
+		for _, i := range component {
+			componentOf[i] = c
+		}
+	}
+
+	// the level of a component is one more than the highest level it refers
+	// to. Tarjan's algorithm finds components after the ones they refer to,
+	// so those levels are already known.
+	componentLevels := make([]int, len(components))
+	for c, component := range components {
+		for _, i := range component {
+			for _, j := range references[i] {
+				if componentOf[j] != c && componentLevels[componentOf[j]]+1 > componentLevels[c] {
+					componentLevels[c] = componentLevels[componentOf[j]] + 1
+				}
+			}
+		}
+	}
+
+	for c, component := range components {
+		for len(levels) <= componentLevels[c] {
+			levels = append(levels, []Table{})
+		}
+		componentTables := []Table{}
+		for _, i := range component {
+			componentTables = append(componentTables, sorted[i])
+		}
+		sortTables(componentTables)
+		levels[componentLevels[c]] = append(levels[componentLevels[c]], componentTables...)
+		if len(component) > 1 {
+			cycles = append(cycles, componentTables)
+		}
+	}
+	for _, level := range levels {
+		sortTables(level)
+	}
+	return levels, cycles
+}
+
+func (t Table) key() Table {
+	return Table{Catalog: t.Catalog, Schema: t.Schema, Name: t.Name}
+}
+
+func sortTables(tables []Table) {
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].QuotedName("") < tables[j].QuotedName("")
+	})
+}
+
+// stronglyConnectedComponents runs Tarjan's algorithm on a graph given as
+// adjacency lists, returning each component after those it has edges to.
+func stronglyConnectedComponents(edges [][]int) [][]int {
+	next := 0
+	indexes := make([]int, len(edges))
+	lowLinks := make([]int, len(edges))
+	onStack := make([]bool, len(edges))
+	for i := range indexes {
+		indexes[i] = -1
+	}
+	stack := []int{}
+	components := [][]int{}
+
+	var visit func(v int)
+	visit = func(v int) {
+		indexes[v] = next
+		lowLinks[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range edges[v] {
+			if indexes[w] == -1 {
+				visit(w)
+				if lowLinks[w] < lowLinks[v] {
+					lowLinks[v] = lowLinks[w]
+				}
+			} else if onStack[w] && indexes[w] < lowLinks[v] {
+				lowLinks[v] = indexes[w]
+			}
+		}
+		if lowLinks[v] != indexes[v] {
+			return
+		}
+		component := []int{}
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+		components = append(components, component)
+	}
+	for v := range edges {
+		if indexes[v] == -1 {
+			visit(v)
+		}
+	}
+	return components
+}
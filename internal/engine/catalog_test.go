@@ -0,0 +1,217 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+)
+
+type catalogTest struct {
+	name        string           // name of test
+	source      data.Source      // source to create the table on and list it from
+	setupQuery  string           // creates the table to look for
+	search      string           // table pattern to search for
+	wantColumns map[string]int64 // expected columns and their primary key positions
+}
+
+var catalogTests = []catalogTest{
+	{
+		name:        "postgresql catalog",
+		source:      postgresqlTestSource,
+		setupQuery:  "drop table if exists catalog_table; create table catalog_table(id int, name varchar(10), primary key (id))",
+		search:      "catalog_%",
+		wantColumns: map[string]int64{"id": 1, "name": 0},
+	},
+	{
+		name:        "mssql catalog",
+		source:      mssqlTestSource,
+		setupQuery:  "drop table if exists catalog_table; create table catalog_table(id int, name varchar(10), primary key (id))",
+		search:      "catalog_%",
+		wantColumns: map[string]int64{"id": 1, "name": 0},
+	},
+	{
+		name:        "mysql catalog",
+		source:      mysqlTestSource,
+		setupQuery:  "create table if not exists catalog_table(id int, name varchar(10), primary key (id))",
+		search:      "catalog_%",
+		wantColumns: map[string]int64{"id": 1, "name": 0},
+	},
+	{
+		name:        "sqlite catalog",
+		source:      sqliteTestSource,
+		setupQuery:  "create table if not exists catalog_table(id int, name varchar(10), primary key (id))",
+		search:      "catalog_%",
+		wantColumns: map[string]int64{"id": 1, "name": 0},
+	},
+}
+
+func TestCatalog(t *testing.T) {
+	for _, tt := range catalogTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var err error
+			tt.source.Db, err = sql.Open(
+				"odbc",
+				tt.source.OdbcDsn,
+			)
+			if err != nil {
+				t.Fatalf("error running sql.Open: %v", err)
+			}
+			defer tt.source.Db.Close()
+
+			_, err = tt.source.Db.Exec(tt.setupQuery)
+			if err != nil {
+				t.Fatalf("error creating table: %v", err)
+			}
+
+			catalogSearch := data.CatalogSearch{Source: tt.source, Table: tt.search}
+
+			tables, err := catalog.ListTables(context.Background(), catalogSearch)
+			if err != nil {
+				t.Fatalf("error listing tables: %v", err)
+			}
+			if len(tables) != 1 || tables[0].Name != "catalog_table" {
+				t.Fatalf("wanted catalog_table, got %+v", tables)
+			}
+
+			schemas, err := catalog.ListSchemas(context.Background(), catalogSearch)
+			if err != nil {
+				t.Fatalf("error listing schemas: %v", err)
+			}
+			if len(schemas) != 1 || schemas[0].Name != tables[0].Schema {
+				t.Fatalf("wanted schema %v, got %+v", tables[0].Schema, schemas)
+			}
+
+			columns, err := catalog.ListColumns(context.Background(), catalogSearch)
+			if err != nil {
+				t.Fatalf("error listing columns: %v", err)
+			}
+			if len(columns) != len(tt.wantColumns) {
+				t.Fatalf("wanted %v columns, got %+v", len(tt.wantColumns), columns)
+			}
+			for _, column := range columns {
+				primaryKeyPosition, ok := tt.wantColumns[column.Name]
+				if !ok {
+					t.Fatalf("unexpected column %v", column.Name)
+				}
+				if column.PrimaryKeyPosition != primaryKeyPosition {
+					t.Fatalf("wanted column %v at primary key position %v, got %v", column.Name, primaryKeyPosition, column.PrimaryKeyPosition)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"wide_table", "wide_table", true},
+		{"wide_table", "wideXtable", true},
+		{"wide%", "wide_table", true},
+		{"%table", "wide_table", true},
+		{"%", "anything", true},
+		{"wide", "wide_table", false},
+		{"wide_", "wide", false},
+		{"a.b", "axb", false},
+		{"a.b", "a.b", true},
+	}
+	for _, tt := range tests {
+		got := catalog.MatchPattern(tt.pattern, tt.name)
+		if got != tt.want {
+			t.Errorf("MatchPattern(%q, %q) = %v, wanted %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeSearchPattern(t *testing.T) {
+	tests := []struct {
+		name   string
+		escape string
+		want   string
+	}{
+		{"my_schema", `\`, `my\_schema`},
+		{"100%", `\`, `100\%`},
+		{`a\b`, `\`, `a\\b`},
+		{"my_schema", "", "my_schema"},
+	}
+	for _, tt := range tests {
+		got := catalog.EscapeSearchPattern(tt.name, tt.escape)
+		if got != tt.want {
+			t.Errorf("EscapeSearchPattern(%q, %q) = %q, wanted %q", tt.name, tt.escape, got, tt.want)
+		}
+	}
+}
+
+func TestLoadOrder(t *testing.T) {
+	table := func(name string) catalog.Table { return catalog.Table{Schema: "public", Name: name, Type: "TABLE"} }
+	foreignKey := func(from, to string) catalog.ForeignKey {
+		return catalog.ForeignKey{Table: catalog.Table{Schema: "public", Name: from}, ReferencedTable: catalog.Table{Schema: "public", Name: to}}
+	}
+	names := func(tables [][]catalog.Table) [][]string {
+		result := [][]string{}
+		for _, group := range tables {
+			groupNames := []string{}
+			for _, table := range group {
+				groupNames = append(groupNames, table.Name)
+			}
+			result = append(result, groupNames)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		tables      []catalog.Table
+		foreignKeys []catalog.ForeignKey
+		wantLevels  [][]string
+		wantCycles  [][]string
+	}{
+		{
+			name:       "no foreign keys",
+			tables:     []catalog.Table{table("b"), table("a")},
+			wantLevels: [][]string{{"a", "b"}},
+			wantCycles: [][]string{},
+		},
+		{
+			name:        "chain",
+			tables:      []catalog.Table{table("orders"), table("customers"), table("order_lines")},
+			foreignKeys: []catalog.ForeignKey{foreignKey("orders", "customers"), foreignKey("order_lines", "orders")},
+			wantLevels:  [][]string{{"customers"}, {"orders"}, {"order_lines"}},
+			wantCycles:  [][]string{},
+		},
+		{
+			name:        "self reference and outside table",
+			tables:      []catalog.Table{table("employees")},
+			foreignKeys: []catalog.ForeignKey{foreignKey("employees", "employees"), foreignKey("employees", "departments")},
+			wantLevels:  [][]string{{"employees"}},
+			wantCycles:  [][]string{},
+		},
+		{
+			name:        "cycle",
+			tables:      []catalog.Table{table("a"), table("b"), table("c"), table("d")},
+			foreignKeys: []catalog.ForeignKey{foreignKey("a", "b"), foreignKey("b", "a"), foreignKey("a", "c"), foreignKey("d", "b")},
+			wantLevels:  [][]string{{"c"}, {"a", "b"}, {"d"}},
+			wantCycles:  [][]string{{"a", "b"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			levels, cycles := catalog.LoadOrder(tt.tables, tt.foreignKeys)
+			if !reflect.DeepEqual(names(levels), tt.wantLevels) {
+				t.Fatalf("wanted levels %v, got %v", tt.wantLevels, names(levels))
+			}
+			if !reflect.DeepEqual(names(cycles), tt.wantCycles) {
+				t.Fatalf("wanted cycles %v, got %v", tt.wantCycles, names(cycles))
+			}
+		})
+	}
+}
@@ -1,13 +1,25 @@
 package engine
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
-	_ "github.com/sqlpipe/odbc"
+	"github.com/sqlpipe/odbc"
 
 	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+	"github.com/sqlpipe/sqlpipe/internal/engine/pools"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/dialects"
+	"github.com/sqlpipe/sqlpipe/internal/validator"
 )
 
 type connectionTest struct {
@@ -32,16 +44,59 @@ var connectionTests = []connectionTest{
 		name:   "snowflake connection test",
 		source: snowflakeTestSource,
 	},
+	{
+		name:   "oracle connection test",
+		source: oracleTestSource,
+	},
+	{
+		name:   "sqlite connection test",
+		source: sqliteTestSource,
+	},
+	{
+		name:   "duckdb connection test",
+		source: duckdbTestSource,
+	},
+	{
+		name:   "clickhouse connection test",
+		source: clickhouseTestSource,
+	},
+	{
+		name:   "mariadb connection test",
+		source: mariadbTestSource,
+	},
+	{
+		name:   "cockroachdb connection test",
+		source: cockroachdbTestSource,
+	},
+	{
+		name:   "redshift connection test",
+		source: redshiftTestSource,
+	},
+	{
+		name: "postgresql structured connection test",
+		source: data.Source{
+			SystemType: "postgresql",
+			Connection: &data.Connection{Host: "localhost", Database: "postgres", User: "postgres", Password: "Mypass123"},
+		},
+	},
+	{
+		name: "mssql structured connection test",
+		source: data.Source{
+			SystemType: "mssql",
+			Connection: &data.Connection{Host: "localhost", Database: "master", User: "sa", Password: "Mypass123", Options: map[string]string{"TDS_Version": "7.0"}},
+		},
+	},
 }
 
 func TestConnections(t *testing.T) {
 	var err error
 	for _, tt := range connectionTests {
+		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			tt.source.Db, err = sql.Open(
 				"odbc",
-				tt.source.OdbcDsn,
+				tt.source.Dsn(),
 			)
 			if err != nil {
 				t.Fatalf(fmt.Sprintf("error runing sql.Open: %v", err.Error()))
@@ -51,6 +106,504 @@ func TestConnections(t *testing.T) {
 			if err != nil {
 				t.Fatalf(fmt.Sprintf("error pinging DB: %v", err.Error()))
 			}
+
+			serverVersion, err := dialects.DetectServerVersion(context.Background(), tt.source.Db)
+			if err != nil {
+				t.Fatalf("error detecting server version: %v", err)
+			}
+			if serverVersion.Product == "" || serverVersion.Version == "" {
+				t.Fatalf("wanted a product and version, got %+v", serverVersion)
+			}
+
+			// cached, so this works with the context already canceled
+			canceled, cancel := context.WithCancel(context.Background())
+			cancel()
+			cachedVersion, err := dialects.DetectServerVersion(canceled, tt.source.Db)
+			if err != nil || cachedVersion != serverVersion {
+				t.Fatalf("wanted cached version %+v, got %+v, %v", serverVersion, cachedVersion, err)
+			}
+			dialects.ForgetServerVersion(tt.source.Db)
+			_, err = dialects.DetectServerVersion(canceled, tt.source.Db)
+			if err == nil {
+				t.Fatalf("wanted an error detecting the version again with a canceled context")
+			}
+
+			diagnostics, err := catalog.Diagnose(context.Background(), tt.source.Db, tt.source.SystemType)
+			if err != nil {
+				t.Fatalf("error diagnosing connection: %v", err)
+			}
+			if diagnostics.DriverName == "" || diagnostics.DbmsName != serverVersion.Product {
+				t.Fatalf("wanted a driver name and dbms %v, got %+v", serverVersion.Product, diagnostics)
+			}
+			if diagnostics.SchemaError != nil {
+				t.Fatalf("error getting current schema: %+v", diagnostics.SchemaError)
+			}
+
+			_, err = catalog.Latency(context.Background(), tt.source.Db, tt.source.SystemType)
+			if err != nil {
+				t.Fatalf("error measuring latency: %v", err)
+			}
+		})
+	}
+}
+
+var connectionDsnTests = []struct {
+	name       string
+	systemType string
+	connection data.Connection
+	expected   string
+}{
+	{
+		name:       "postgresql default port",
+		systemType: "postgresql",
+		connection: data.Connection{Host: "localhost", Database: "postgres", User: "postgres", Password: "Mypass123"},
+		expected:   "Driver=PostgreSQL;Server=localhost;Port=5432;Database=postgres;Uid=postgres;Pwd=Mypass123;",
+	},
+	{
+		name:       "mssql options",
+		systemType: "mssql",
+		connection: data.Connection{Host: "localhost", Port: 1434, User: "sa", Password: "Mypass123", Options: map[string]string{"TDS_Version": "7.0"}},
+		expected:   "Driver=MSSQL;Server=localhost;Port=1434;Uid=sa;Pwd=Mypass123;TDS_Version=7.0;",
+	},
+	{
+		name:       "oracle address",
+		systemType: "oracle",
+		connection: data.Connection{Host: "localhost", Database: "XEPDB1", User: "system", Password: "Mypass123"},
+		expected:   "Driver=Oracle;DBQ=localhost:1521/XEPDB1;Uid=system;Pwd=Mypass123;",
+	},
+	{
+		name:       "password with separators",
+		systemType: "mysql",
+		connection: data.Connection{Host: "localhost", User: "root", Password: "a;b}c"},
+		expected:   "Driver=MySQL;Server=localhost;Port=3306;Uid=root;Pwd={a;b}}c};",
+	},
+	{
+		name:       "duckdb file",
+		systemType: "duckdb",
+		connection: data.Connection{Database: "/tmp/sqlpipe.duckdb"},
+		expected:   "Driver=DuckDB;Database=/tmp/sqlpipe.duckdb;",
+	},
+}
+
+func TestConnectionDsn(t *testing.T) {
+	for _, tt := range connectionDsnTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dsn := tt.connection.Dsn(tt.systemType)
+			if dsn != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, dsn)
+			}
+		})
+	}
+}
+
+var validateConnectionTests = []struct {
+	name     string
+	source   data.Source
+	expected map[string]string
+}{
+	{
+		name:     "valid",
+		source:   data.Source{SystemType: "postgresql", Connection: &data.Connection{Host: "localhost", Database: "postgres", User: "postgres"}},
+		expected: map[string]string{},
+	},
+	{
+		name:   "missing fields",
+		source: data.Source{SystemType: "oracle", Connection: &data.Connection{Port: 70000}},
+		expected: map[string]string{
+			"source->connection->host":     "must be provided",
+			"source->connection->database": "must be provided",
+			"source->connection->user":     "must be provided",
+			"source->connection->port":     "must be between 1 and 65535, or 0 for the default port",
+		},
+	},
+	{
+		name:     "reserved option",
+		source:   data.Source{SystemType: "mssql", Connection: &data.Connection{Host: "localhost", User: "sa", Options: map[string]string{"PWD": "x"}}},
+		expected: map[string]string{"source->connection->options": "must not set PWD, which the connection sets"},
+	},
+	{
+		name:     "no system type",
+		source:   data.Source{Connection: &data.Connection{Host: "localhost"}},
+		expected: map[string]string{"source->system_type": "must be provided with source->connection"},
+	},
+	{
+		name:     "both dsn and connection",
+		source:   data.Source{OdbcDsn: "DSN=x", SystemType: "sqlite", Connection: &data.Connection{Database: "/tmp/x"}},
+		expected: map[string]string{"source->connection": "must not be provided with source->odbc_dsn"},
+	},
+}
+
+func TestValidateConnection(t *testing.T) {
+	for _, tt := range validateConnectionTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			data.ValidateSource(v, tt.source)
+			if !reflect.DeepEqual(v.Errors, tt.expected) {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, v.Errors)
+			}
+		})
+	}
+}
+
+var validateTargetTests = []struct {
+	name     string
+	target   data.Target
+	expected map[string]string
+}{
+	{
+		name:     "engine with arguments",
+		target:   data.Target{SystemType: "clickhouse", OdbcDsn: "DSN=x", Table: "t", Engine: "ReplacingMergeTree(version)", OrderBy: []string{"id"}},
+		expected: map[string]string{},
+	},
+	{
+		name:     "engine with trailing clause",
+		target:   data.Target{SystemType: "clickhouse", OdbcDsn: "DSN=x", Table: "t", Engine: "MergeTree partition by x"},
+		expected: map[string]string{"target->engine": "must be an engine name, optionally followed by its arguments in parentheses"},
+	},
+	{
+		name:     "engine after closed arguments",
+		target:   data.Target{SystemType: "clickhouse", OdbcDsn: "DSN=x", Table: "t", Engine: "MergeTree() order by tuple(); drop table t"},
+		expected: map[string]string{"target->engine": "must be an engine name, optionally followed by its arguments in parentheses"},
+	},
+	{
+		name:     "order by on another target",
+		target:   data.Target{SystemType: "postgresql", OdbcDsn: "DSN=x", Table: "t", OrderBy: []string{"id"}},
+		expected: map[string]string{"target->order_by": "is only supported for clickhouse targets"},
+	},
+}
+
+func TestValidateTarget(t *testing.T) {
+	for _, tt := range validateTargetTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			data.ValidateTarget(v, tt.target, dialects.Names())
+			if !reflect.DeepEqual(v.Errors, tt.expected) {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, v.Errors)
+			}
+		})
+	}
+}
+
+var redactDsnTests = []struct {
+	name     string
+	dsn      string
+	expected string
+}{
+	{
+		name:     "password",
+		dsn:      "Driver=PostgreSQL;Server=localhost;Uid=postgres;Pwd=Mypass123;",
+		expected: "Driver=PostgreSQL;Server=localhost;Uid=postgres;Pwd=********;",
+	},
+	{
+		name:     "braced password",
+		dsn:      "Driver=MySQL;PASSWORD={a;b}}c};Server=localhost",
+		expected: "Driver=MySQL;PASSWORD=********;Server=localhost",
+	},
+	{
+		name:     "token",
+		dsn:      "Driver=Snowflake;Server=x;Authenticator=oauth;Token=abc",
+		expected: "Driver=Snowflake;Server=x;Authenticator=oauth;Token=********",
+	},
+}
+
+func TestRedactDsn(t *testing.T) {
+	for _, tt := range redactDsnTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			redacted := data.RedactDsn(tt.dsn)
+			if redacted != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, redacted)
+			}
 		})
 	}
 }
+
+func TestConnectionRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "connections.json")
+	key := bytes.Repeat([]byte{7}, 32)
+
+	registry, err := data.NewConnectionRegistry(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = registry.Insert(data.NamedConnection{
+		Name:       "warehouse",
+		SystemType: "postgresql",
+		Connection: &data.Connection{Host: "localhost", Database: "postgres", User: "postgres", Password: "Mypass123", Options: map[string]string{"sslmode": "require"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = registry.Insert(data.NamedConnection{Name: "warehouse", OdbcDsn: "DSN=x"})
+	if err != data.ErrDuplicateConnection {
+		t.Fatalf("wanted ErrDuplicateConnection, got %v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(contents, []byte("Mypass123")) || bytes.Contains(contents, []byte("sslmode")) {
+		t.Fatalf("connections file holds unencrypted secrets:\n%s", contents)
+	}
+
+	_, err = data.NewConnectionRegistry(path, bytes.Repeat([]byte{8}, 32))
+	if err == nil {
+		t.Fatal("wanted an error loading connections with the wrong key")
+	}
+
+	registry, err = data.NewConnectionRegistry(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := data.Source{ConnectionName: "warehouse"}
+	v := validator.New()
+	registry.ResolveSource(v, &source)
+	if !v.Valid() {
+		t.Fatal(v.Errors)
+	}
+	expectedDsn := "Driver=PostgreSQL;Server=localhost;Port=5432;Database=postgres;Uid=postgres;Pwd=Mypass123;sslmode=require;"
+	if source.Dsn() != expectedDsn {
+		t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", expectedDsn, source.Dsn())
+	}
+
+	namedConnection, err := registry.Get("warehouse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if namedConnection.Redacted().Connection.Password != "********" {
+		t.Fatalf("password was not redacted: %v", namedConnection.Redacted().Connection.Password)
+	}
+	if namedConnection.Connection.Password != "Mypass123" {
+		t.Fatal("redacting changed the stored connection")
+	}
+
+	target := data.Target{ConnectionName: "missing"}
+	v = validator.New()
+	registry.ResolveTarget(v, &target)
+	expected := map[string]string{"target->connection_name": data.ErrConnectionNotFound.Error()}
+	if !reflect.DeepEqual(v.Errors, expected) {
+		t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", expected, v.Errors)
+	}
+
+	err = registry.Delete("warehouse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = registry.Delete("warehouse")
+	if err != data.ErrConnectionNotFound {
+		t.Fatalf("wanted ErrConnectionNotFound, got %v", err)
+	}
+}
+
+func TestSecretResolver(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "pg"), []byte("Mypass123\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside := t.TempDir()
+	err = os.WriteFile(filepath.Join(outside, "pg"), []byte("nope"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(filepath.Join(outside, "pg"), filepath.Join(dir, "link"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SQLPIPE_TEST_PASSWORD", "Mypass123")
+	t.Setenv("OTHER_PASSWORD", "nope")
+
+	resolver := &data.SecretResolver{EnvPrefixes: []string{"SQLPIPE_"}, Dirs: []string{dir}}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "no references", value: "Driver=PostgreSQL;Pwd=x;", expected: "Driver=PostgreSQL;Pwd=x;"},
+		{name: "env", value: "Pwd=${env:SQLPIPE_TEST_PASSWORD};", expected: "Pwd=Mypass123;"},
+		{name: "file", value: "Pwd=${file:" + filepath.Join(dir, "pg") + "};", expected: "Pwd=Mypass123;"},
+		{name: "env not allowed", value: "${env:OTHER_PASSWORD}", wantErr: true},
+		{name: "env not set", value: "${env:SQLPIPE_MISSING}", wantErr: true},
+		{name: "file outside dirs", value: "${file:" + filepath.Join(outside, "pg") + "}", wantErr: true},
+		{name: "dot dot", value: "${file:" + dir + "/../" + filepath.Base(outside) + "/pg}", wantErr: true},
+		{name: "symlink outside dirs", value: "${file:" + filepath.Join(dir, "link") + "}", wantErr: true},
+		{name: "relative path", value: "${file:pg}", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := resolver.Resolve(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("wanted an error, got %v", resolved)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resolved != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, resolved)
+			}
+		})
+	}
+
+	options := map[string]string{"sslpassword": "${env:SQLPIPE_TEST_PASSWORD}", "bad": "${env:OTHER_PASSWORD}"}
+	source := data.Source{Connection: &data.Connection{Password: "${file:" + filepath.Join(dir, "pg") + "}", Options: options}}
+	v := validator.New()
+	resolver.ResolveSource(v, &source)
+	if source.Connection.Password != "Mypass123" || source.Connection.Options["sslpassword"] != "Mypass123" {
+		t.Fatalf("wanted the password and option resolved, got %+v", source.Connection)
+	}
+	if _, ok := v.Errors["source->connection->options->bad"]; !ok || len(v.Errors) != 1 {
+		t.Fatalf("wanted an error for the bad option, got %v", v.Errors)
+	}
+	if options["sslpassword"] != "${env:SQLPIPE_TEST_PASSWORD}" {
+		t.Fatal("resolving changed the original options")
+	}
+}
+
+func TestPools(t *testing.T) {
+	manager := pools.New(pools.Config{MaxIdleConns: 2})
+	defer manager.Close()
+
+	dsn := postgresqlTestSource.Dsn()
+	first, releaseFirst, err := manager.Get(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf("error getting pool: %v", err)
+	}
+	second, releaseSecond, err := manager.Get(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf("error getting pool: %v", err)
+	}
+	if first != second {
+		t.Fatal("wanted the same pool for the same dsn")
+	}
+	releaseFirst()
+	releaseSecond()
+
+	stats := manager.Stats()
+	if _, ok := stats["pool-1"]; !ok || len(stats) != 1 {
+		t.Fatalf("wanted stats keyed by pool id, got %v", stats)
+	}
+
+	err = manager.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = manager.Get(context.Background(), dsn)
+	if err != pools.ErrClosed {
+		t.Fatalf("wanted ErrClosed, got %v", err)
+	}
+}
+
+var diagnosticErrorTests = []struct {
+	name     string
+	err      error
+	expected catalog.DiagnosticError
+}{
+	{
+		name: "odbc error",
+		err: &odbc.Error{APIName: "SQLDriverConnect", Diag: []odbc.DiagRecord{
+			{State: "28000", NativeError: 18456, Message: "Login failed for user 'sa'."},
+			{State: "01000", Message: "General warning"},
+		}},
+		expected: catalog.DiagnosticError{SqlState: "28000", NativeError: 18456, Function: "SQLDriverConnect", Message: "Login failed for user 'sa'."},
+	},
+	{
+		name:     "bad connection",
+		err:      driver.ErrBadConn,
+		expected: catalog.DiagnosticError{SqlState: "08S01", Message: "communication link failure"},
+	},
+	{
+		name:     "other error",
+		err:      errors.New("connection refused"),
+		expected: catalog.DiagnosticError{Message: "error pinging: connection refused"},
+	},
+}
+
+func TestNewDiagnosticError(t *testing.T) {
+	for _, tt := range diagnosticErrorTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			diagnosticError := catalog.NewDiagnosticError(fmt.Errorf("error pinging: %w", tt.err))
+			if diagnosticError != tt.expected {
+				t.Fatalf("\nwanted:\n%+v\n\ngot:\n%+v", tt.expected, diagnosticError)
+			}
+		})
+	}
+}
+
+var driverSystemTypesTests = []struct {
+	driver   string
+	expected []string
+}{
+	{driver: "PostgreSQL", expected: []string{"cockroachdb", "postgresql", "redshift"}},
+	{driver: "mysql", expected: []string{"mariadb", "mysql"}},
+	{driver: "SQLite3", expected: []string{"sqlite"}},
+	{driver: "FreeTDS", expected: []string{}},
+}
+
+func TestDriverSystemTypes(t *testing.T) {
+	for _, tt := range driverSystemTypesTests {
+		tt := tt
+		t.Run(tt.driver, func(t *testing.T) {
+			systemTypes := data.DriverSystemTypes(tt.driver)
+			if !reflect.DeepEqual(systemTypes, tt.expected) {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, systemTypes)
+			}
+		})
+	}
+}
+
+// TestDriverTemplates checks that each system type's connections name a
+// driver the build's odbcinst.ini templates install.
+func TestDriverTemplates(t *testing.T) {
+	templates, err := filepath.Glob("../../build/*.driver.template")
+	if err != nil || len(templates) == 0 {
+		t.Fatalf("error finding driver templates: %v", err)
+	}
+	sections := map[string]bool{}
+	for _, template := range templates {
+		contents, err := os.ReadFile(template)
+		if err != nil {
+			t.Fatalf("error reading %v: %v", template, err)
+		}
+		for _, line := range strings.Split(string(contents), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+				sections[line[1:len(line)-1]] = true
+			}
+		}
+	}
+
+	for _, systemType := range data.ConnectionSystemTypes() {
+		dsn := data.Connection{}.Dsn(systemType)
+		driver := strings.TrimPrefix(strings.SplitN(dsn, ";", 2)[0], "Driver=")
+		if !sections[driver] {
+			t.Errorf("%v connections use driver %v, which no driver template installs", systemType, driver)
+		}
+	}
+}
+
+func TestDrivers(t *testing.T) {
+	drivers, _, err := catalog.ListDrivers()
+	if err != nil {
+		t.Fatalf("error listing drivers: %v", err)
+	}
+	for _, driver := range drivers {
+		if driver.Name == "PostgreSQL" {
+			if !reflect.DeepEqual(driver.SystemTypes, []string{"cockroachdb", "postgresql", "redshift"}) {
+				t.Fatalf("wanted the postgresql system types, got %v", driver.SystemTypes)
+			}
+			return
+		}
+	}
+	t.Fatalf("wanted the PostgreSQL driver, got %+v", drivers)
+}
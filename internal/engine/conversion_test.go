@@ -0,0 +1,279 @@
+package engine
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+var pgArrayToJsonTests = []struct {
+	name        string
+	literal     string
+	elementKind string
+	expected    string
+	expectedErr bool
+}{
+	{name: "empty", literal: "{}", elementKind: shared.PgArrayText, expected: "[]"},
+	{name: "integers", literal: "{1,2,NULL}", elementKind: shared.PgArrayNumeric, expected: "[1,2,null]"},
+	{name: "nested", literal: "{{1.5,2},{3,-4e2}}", elementKind: shared.PgArrayNumeric, expected: "[[1.5,2],[3,-4e2]]"},
+	{name: "numeric nan", literal: "{NaN,1}", elementKind: shared.PgArrayNumeric, expected: `["NaN",1]`},
+	{name: "booleans", literal: "{t,f,NULL}", elementKind: shared.PgArrayBool, expected: "[true,false,null]"},
+	{name: "quoted text", literal: `{"a b","say \"hi\"",plain,"NULL"}`, elementKind: shared.PgArrayText, expected: `["a b","say \"hi\"","plain","NULL"]`},
+	{name: "explicit dimensions", literal: "[0:1]={7,8}", elementKind: shared.PgArrayNumeric, expected: "[7,8]"},
+	{name: "unterminated", literal: "{1,2", elementKind: shared.PgArrayNumeric, expectedErr: true},
+}
+
+func TestPgArrayToJson(t *testing.T) {
+	for _, tt := range pgArrayToJsonTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := shared.PgArrayToJson(tt.literal, tt.elementKind)
+			if tt.expectedErr {
+				if err == nil {
+					t.Fatalf("wanted error, got result %v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to convert array, err: %v", err)
+			}
+			if result != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, result)
+			}
+		})
+	}
+}
+
+var pgIntervalToIso8601Tests = []struct {
+	name     string
+	interval string
+	expected string
+}{
+	{name: "days and time", interval: "10 days 10:00:00", expected: "P10DT10H"},
+	{name: "all fields", interval: "1 year 2 mons 3 days 04:05:06.5", expected: "P1Y2M3DT4H5M6.5S"},
+	{name: "negative parts", interval: "-1 days +02:03:00", expected: "P-1DT2H3M"},
+	{name: "negative time", interval: "-00:00:10", expected: "PT-10S"},
+	{name: "zero", interval: "00:00:00", expected: "PT0S"},
+	{name: "verbose ago", interval: "@ 1 year 2 mons ago", expected: "P-1Y-2M"},
+	{name: "already iso", interval: "P1Y2M", expected: "P1Y2M"},
+}
+
+func TestPgIntervalToIso8601(t *testing.T) {
+	for _, tt := range pgIntervalToIso8601Tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := shared.PgIntervalToIso8601(tt.interval)
+			if err != nil {
+				t.Fatalf("unable to convert interval, err: %v", err)
+			}
+			if result != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, result)
+			}
+		})
+	}
+}
+
+var pgMoneyToNumericTests = []struct {
+	name        string
+	money       string
+	expected    string
+	expectedErr bool
+}{
+	{name: "grouped", money: "$35,244.33", expected: "35244.33"},
+	{name: "negative", money: "-$0.99", expected: "-0.99"},
+	{name: "parentheses", money: "($1,000.00)", expected: "-1000.00"},
+	{name: "largest", money: "$92,233,720,368,547,758.07", expected: "92233720368547758.07"},
+	{name: "ungrouped", money: "1234.5", expected: "1234.5"},
+	{name: "comma decimal separator", money: "1.234,50 €", expectedErr: true},
+	{name: "misplaced grouping", money: "$12,34.00", expectedErr: true},
+}
+
+func TestPgMoneyToNumeric(t *testing.T) {
+	for _, tt := range pgMoneyToNumericTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := shared.PgMoneyToNumeric(tt.money)
+			if tt.expectedErr {
+				if err == nil {
+					t.Fatalf("wanted error, got result %v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to convert money, err: %v", err)
+			}
+			if result != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, result)
+			}
+		})
+	}
+}
+
+var wkbToWktTests = []struct {
+	name     string
+	wkb      string
+	expected string
+}{
+	{name: "point", wkb: "0101000000000000000000f03f0000000000000040", expected: "POINT(1 2)"},
+	{name: "big endian point", wkb: "00000000013ff00000000000004000000000000000", expected: "POINT(1 2)"},
+	{name: "linestring", wkb: "010200000002000000000000000000000000000000000000000000000000000840000000000000f0bf", expected: "LINESTRING(0 0,3 -1)"},
+	{name: "polygon", wkb: "0103000000010000000400000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000004000000000000000000000000000000000", expected: "POLYGON((0 0,2 0,0 2,0 0))"},
+	{name: "multipoint", wkb: "0104000000020000000101000000000000000000f03f000000000000f03f0101000000000000000000004000000000000000c0", expected: "MULTIPOINT((1 1),(2 -2))"},
+	{name: "collection", wkb: "0107000000010000000101000000000000000000e03f0000000000000000", expected: "GEOMETRYCOLLECTION(POINT(0.5 0))"},
+	{name: "empty collection", wkb: "010700000000000000", expected: "GEOMETRYCOLLECTION EMPTY"},
+	{name: "empty linestring", wkb: "010200000000000000", expected: "LINESTRING EMPTY"},
+	{name: "empty polygon", wkb: "010300000000000000", expected: "POLYGON EMPTY"},
+	{name: "multilinestring with empty member", wkb: "0105000000020000000102000000000000000102000000010000000000000000000000000000000000f03f", expected: "MULTILINESTRING(EMPTY,(0 1))"},
+}
+
+func TestWkbToWkt(t *testing.T) {
+	for _, tt := range wkbToWktTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			wkb, err := hex.DecodeString(tt.wkb)
+			if err != nil {
+				t.Fatalf("unable to decode test wkb, err: %v", err)
+			}
+			result, err := shared.WkbToWkt(wkb)
+			if err != nil {
+				t.Fatalf("unable to convert wkb, err: %v", err)
+			}
+			if result != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMysqlGeometryToWkt(t *testing.T) {
+	geometry, err := hex.DecodeString("e61000000101000000000000000000f03f0000000000000040")
+	if err != nil {
+		t.Fatalf("unable to decode test geometry, err: %v", err)
+	}
+	srid, wkt, err := shared.MysqlGeometryToWkt(geometry)
+	if err != nil {
+		t.Fatalf("unable to convert geometry, err: %v", err)
+	}
+	if srid != 4326 || wkt != "POINT(1 2)" {
+		t.Fatalf("wanted srid 4326 and POINT(1 2), got srid %v and %v", srid, wkt)
+	}
+}
+
+var mysqlEnumValuesTests = []struct {
+	name        string
+	columnType  string
+	expected    []string
+	expectedErr bool
+}{
+	{name: "enum", columnType: "enum('small','medium','large')", expected: []string{"small", "medium", "large"}},
+	{name: "quotes and commas", columnType: "enum('it''s','a,b','')", expected: []string{"it's", "a,b", ""}},
+	{name: "set", columnType: "set('x')", expected: []string{"x"}},
+	{name: "unterminated", columnType: "enum('a)", expectedErr: true},
+	{name: "not a list", columnType: "varchar(10)", expectedErr: true},
+}
+
+func TestMysqlEnumValues(t *testing.T) {
+	for _, tt := range mysqlEnumValuesTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := shared.MysqlEnumValues(tt.columnType)
+			if tt.expectedErr {
+				if err == nil {
+					t.Fatalf("wanted error, got values %q", values)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to read enum values, err: %v", err)
+			}
+			if !reflect.DeepEqual(values, tt.expected) {
+				t.Fatalf("\nwanted:\n%q\n\ngot:\n%q", tt.expected, values)
+			}
+		})
+	}
+}
+
+var oracleTextTests = []struct {
+	name     string
+	value    interface{}
+	expected string
+}{
+	{name: "null", value: nil, expected: "null,"},
+	{name: "empty string is null", value: []byte(""), expected: "null,"},
+	{name: "quotes", value: []byte("it's"), expected: "'it''s',"},
+	{name: "long text", value: strings.Repeat("a", 4001), expected: "to_clob('" + strings.Repeat("a", 4000) + "')||to_clob('a'),"},
+}
+
+func TestOracleText(t *testing.T) {
+	for _, tt := range oracleTextTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := shared.OracleTextXnull(tt.value, ",")
+			if err != nil {
+				t.Fatalf("unable to format value, err: %v", err)
+			}
+			if result != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOracleSplitText(t *testing.T) {
+	// a multi byte character is never split across chunks
+	chunks := shared.OracleSplitText("aaaéé", 4)
+	expected := []string{"aaa", "éé"}
+	if !reflect.DeepEqual(chunks, expected) {
+		t.Fatalf("\nwanted:\n%q\n\ngot:\n%q", expected, chunks)
+	}
+}
+
+func TestDuckdbBlob(t *testing.T) {
+	result, err := shared.DuckdbBlobXnull([]byte{0x00, 0xab, 0x27}, ",")
+	if err != nil {
+		t.Fatalf("unable to format value, err: %v", err)
+	}
+	expected := `'\x00\xAB\x27'::blob,`
+	if result != expected {
+		t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", expected, result)
+	}
+}
+
+func TestMssqlDatetimeoffset(t *testing.T) {
+	value := time.Date(2014, 1, 10, 20, 5, 4, 500000000, time.FixedZone("", 2*60*60))
+	result, err := shared.CastToTimeFormatToMssqlDatetimeoffsetStringXnull(value, ",")
+	if err != nil {
+		t.Fatalf("unable to format value, err: %v", err)
+	}
+	expected := "'2014-01-10 18:05:04.5 +00:00',"
+	if result != expected {
+		t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", expected, result)
+	}
+}
+
+var clickhouseTableEngineTests = []struct {
+	name     string
+	engine   string
+	orderBy  []string
+	expected string
+}{
+	{name: "default", expected: " engine = MergeTree order by tuple()"},
+	{name: "order by", orderBy: []string{"a", "b"}, expected: " engine = MergeTree order by (a,b) settings allow_nullable_key = 1"},
+	{name: "engine", engine: "ReplacingMergeTree", orderBy: []string{"id"}, expected: " engine = ReplacingMergeTree order by (id) settings allow_nullable_key = 1"},
+}
+
+func TestClickhouseTableEngine(t *testing.T) {
+	for _, tt := range clickhouseTableEngineTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result := shared.ClickhouseTableEngine(tt.engine, tt.orderBy)
+			if result != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -47,6 +47,33 @@ var createTests = []setupTest{
 		checkQuery:  "select * from wide_table",
 		checkResult: " MYNUMBER | MYINT | MYFLOAT | MYVARCHAR | MYBINARY | MYBOOLEAN | MYDATE | MYTIME | MYTIMESTAMP_LTZ | MYTIMESTAMP_NTZ | MYTIMESTAMP_TZ | MYVARIANT | MYOBJECT | MYARRAY | MYGEOGRAPHY \n----------+-------+---------+-----------+----------+-----------+--------+--------+-----------------+-----------------+----------------+-----------+----------+---------+-------------\n(0 rows)",
 	},
+	// Oracle
+	{
+		name:        "oracle wide_table create",
+		source:      oracleTestSource,
+		testQuery:   `create table wide_table (mynumber number(10,5), myinteger integer, mybinary_float binary_float, mybinary_double binary_double, mychar char(3), myvarchar2 varchar2(100), mynvarchar2 nvarchar2(100), myclob clob, myraw raw(10), myblob blob, mydate date, mytimestamp timestamp, mytimestamptz timestamp with time zone)`,
+		expectedErr: "Stmt did not create a result set",
+		checkQuery:  "select * from wide_table",
+		checkResult: " MYNUMBER | MYINTEGER | MYBINARY_FLOAT | MYBINARY_DOUBLE | MYCHAR | MYVARCHAR2 | MYNVARCHAR2 | MYCLOB | MYRAW | MYBLOB | MYDATE | MYTIMESTAMP | MYTIMESTAMPTZ \n----------+-----------+----------------+-----------------+--------+------------+-------------+--------+-------+--------+--------+-------------+---------------\n(0 rows)",
+	},
+	// SQLite
+	{
+		name:        "sqlite wide_table create",
+		source:      sqliteTestSource,
+		testQuery:   `create table wide_table (myinteger integer, myreal real, mynumeric numeric, mytext text, myblob blob);`,
+		expectedErr: "Stmt did not create a result set",
+		checkQuery:  "select * from wide_table",
+		checkResult: " myinteger | myreal | mynumeric | mytext | myblob \n-----------+--------+-----------+--------+--------\n(0 rows)",
+	},
+	// DuckDB
+	{
+		name:        "duckdb wide_table create",
+		source:      duckdbTestSource,
+		testQuery:   `create table wide_table (mybigint bigint, mydouble double, mydecimal decimal(10,5), myvarchar varchar, myblob blob, myboolean boolean, mydate date, mytimestamp timestamp, myuuid uuid);`,
+		expectedErr: "Stmt did not create a result set",
+		checkQuery:  "select * from wide_table",
+		checkResult: " mybigint | mydouble | mydecimal | myvarchar | myblob | myboolean | mydate | mytimestamp | myuuid \n----------+----------+-----------+-----------+--------+-----------+--------+-------------+--------\n(0 rows)",
+	},
 }
 
 func TestCreate(t *testing.T) {
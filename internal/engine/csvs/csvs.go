@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
 )
 
 func WriteCsvToFile(
@@ -26,9 +27,12 @@ func WriteCsvToFile(
 	if err != nil {
 		return fmt.Errorf("error getting column types: %v", err.Error())
 	}
-	colDbTypes := []string{}
-	for _, colType := range colTypes {
-		colDbTypes = append(colDbTypes, colType.DatabaseTypeName())
+	colFormatters := make([]func(value interface{}) (string, error), len(colTypes))
+	for i, colType := range colTypes {
+		colFormatters[i], err = getFormatter(colType.DatabaseTypeName())
+		if err != nil {
+			return err
+		}
 	}
 
 	csvWriter := csv.NewWriter(file)
@@ -55,7 +59,7 @@ func WriteCsvToFile(
 	for i := 1; rows.Next(); i++ {
 		rows.Scan(valPtrs...)
 		for j := 0; j < numCols; j++ {
-			rowVals[j], err = formatters[colDbTypes[j]](vals[j])
+			rowVals[j], err = colFormatters[j](vals[j])
 			if err != nil {
 				return fmt.Errorf("error formatting values for csv file: %v", err.Error())
 			}
@@ -73,3 +77,13 @@ func WriteCsvToFile(
 
 	return nil
 }
+
+// getFormatter looks up the csv formatter for a column, preferring the
+// source's native type name and falling back to the generic ODBC type.
+func getFormatter(colDbType string) (func(value interface{}) (string, error), error) {
+	formatter, ok := shared.Lookup(formatters, colDbType)
+	if !ok {
+		return nil, fmt.Errorf("no csv formatter for column type %v", colDbType)
+	}
+	return formatter, nil
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
 )
 
 var formatters = map[string]func(value interface{}) (string, error){
@@ -38,6 +40,39 @@ var formatters = map[string]func(value interface{}) (string, error){
 	`SQL_UNSIGNED_OFFSET`: csvPrintRaw,
 	`SQL_SS_XML`:          csvPrintRaw,
 	`SQL_SS_TIME2`:        csvPrintRaw,
+	`SQL_SS_VARIANT`:      csvCastToBytesCastToStringEscape,
+	`SQL_SS_UDT`:          csvCastToBytesPrintHex,
+	`uniqueidentifier`:    csvPrintText,
+	`uuid`:                csvPrintText,
+	`xml`:                 csvPrintText,
+	`json`:                csvPrintText,
+	`jsonb`:               csvPrintText,
+	`array`:               csvPgArrayToJson(shared.PgArrayText),
+	`int2[]`:              csvPgArrayToJson(shared.PgArrayNumeric),
+	`int4[]`:              csvPgArrayToJson(shared.PgArrayNumeric),
+	`int8[]`:              csvPgArrayToJson(shared.PgArrayNumeric),
+	`float4[]`:            csvPgArrayToJson(shared.PgArrayNumeric),
+	`float8[]`:            csvPgArrayToJson(shared.PgArrayNumeric),
+	`numeric[]`:           csvPgArrayToJson(shared.PgArrayNumeric),
+	`oid[]`:               csvPgArrayToJson(shared.PgArrayNumeric),
+	`bool[]`:              csvPgArrayToJson(shared.PgArrayBool),
+	`interval`:            csvPgIntervalToIso8601,
+	`inet`:                csvPrintText,
+	`cidr`:                csvPrintText,
+	`money`:               csvPgMoneyToNumeric,
+	`bit`:                 csvPrintText,
+	`varbit`:              csvPrintText,
+	`bit varying`:         csvPrintText,
+	`bigint unsigned`:     csvPrintText,
+	`geometry`:            csvMysqlGeometryToWkt,
+
+	// SQL Server
+	`SQL_SS_TIMESTAMPOFFSET`: csvCastToBytesCastToStringEscape,
+	`datetimeoffset`:         csvPrintText,
+	`smallmoney`:             csvPgMoneyToNumeric,
+	`sql_variant`:            csvCastToBytesCastToStringEscape,
+	`hierarchyid`:            csvCastToBytesPrintHex,
+	`rowversion`:             csvCastToBytesPrintHex,
 }
 
 func csvPrintRaw(value interface{}) (string, error) {
@@ -47,6 +82,77 @@ func csvPrintRaw(value interface{}) (string, error) {
 	return fmt.Sprintf(`%v`, value), nil
 }
 
+func csvPrintText(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case []byte:
+		return string(value), nil
+	case string:
+		return value, nil
+	}
+	return ``, errors.New(`csvPrintText unable to cast value to bytes or string`)
+}
+
+func csvPgArrayToJson(elementKind string) func(value interface{}) (string, error) {
+	return func(value interface{}) (string, error) {
+		if value == nil {
+			return "", nil
+		}
+		valString, err := csvPrintText(value)
+		if err != nil {
+			return ``, err
+		}
+		return shared.PgArrayToJson(valString, elementKind)
+	}
+}
+
+func csvPgIntervalToIso8601(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	valString, err := csvPrintText(value)
+	if err != nil {
+		return ``, err
+	}
+	return shared.PgIntervalToIso8601(valString)
+}
+
+func csvPgMoneyToNumeric(value interface{}) (string, error) {
+	switch value.(type) {
+	case []byte, string:
+		valString, err := csvPrintText(value)
+		if err != nil {
+			return ``, err
+		}
+		return shared.PgMoneyToNumeric(valString)
+	}
+	return csvPrintRaw(value)
+}
+
+func csvMysqlGeometryToWkt(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	valBytes, ok := value.([]byte)
+	if !ok {
+		return ``, errors.New(`csvMysqlGeometryToWkt unable to cast value to bytes`)
+	}
+	_, wkt, err := shared.MysqlGeometryToWkt(valBytes)
+	return wkt, err
+}
+
+func csvCastToBytesPrintHex(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	valBytes, ok := value.([]byte)
+	if !ok {
+		return ``, errors.New(`csvCastToBytesPrintHex unable to cast value to bytes`)
+	}
+	return fmt.Sprintf(`%x`, valBytes), nil
+}
+
 func csvCastToBoolWriteBinaryEquivalent(value interface{}) (string, error) {
 	if value == nil {
 		return "", nil
@@ -0,0 +1,230 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/dialects"
+)
+
+var dialectTests = []struct {
+	name     string
+	dialect  string
+	command  func(dialect dialects.Dialect) string
+	expected string
+}{
+	{
+		name:     "postgresql quote",
+		dialect:  "postgresql",
+		command:  func(d dialects.Dialect) string { return d.QuoteIdentifier(`my"table`) },
+		expected: `"my""table"`,
+	},
+	{
+		name:     "mssql quote",
+		dialect:  "mssql",
+		command:  func(d dialects.Dialect) string { return d.QuoteIdentifier("my]table") },
+		expected: "[my]]table]",
+	},
+	{
+		name:     "oracle drop",
+		dialect:  "oracle",
+		command:  func(d dialects.Dialect) string { return d.DropTableCommand("system.t") },
+		expected: "drop table system.t",
+	},
+	{
+		name:     "sqlite truncate",
+		dialect:  "sqlite",
+		command:  func(d dialects.Dialect) string { return d.TruncateTableCommand("t") },
+		expected: "delete from t",
+	},
+	{
+		name:     "postgresql keyword identifier",
+		dialect:  "postgresql",
+		command:  func(d dialects.Dialect) string { return dialects.Identifier(d, "order") },
+		expected: `"order"`,
+	},
+	{
+		name:     "mysql mixed case identifier",
+		dialect:  "mysql",
+		command:  func(d dialects.Dialect) string { return dialects.Identifier(d, "MyCol") },
+		expected: "`MyCol`",
+	},
+	{
+		name:     "snowflake plain identifier",
+		dialect:  "snowflake",
+		command:  func(d dialects.Dialect) string { return dialects.Identifier(d, "my_col") },
+		expected: "my_col",
+	},
+	{
+		name:     "mssql table name",
+		dialect:  "mssql",
+		command:  func(d dialects.Dialect) string { return dialects.TableName(d, "dbo", "User") },
+		expected: "dbo.[User]",
+	},
+	{
+		name:     "oracle table name without schema",
+		dialect:  "oracle",
+		command:  func(d dialects.Dialect) string { return dialects.TableName(d, "", "my table") },
+		expected: `"my table"`,
+	},
+	{
+		name:    "clickhouse create",
+		dialect: "clickhouse",
+		command: func(d dialects.Dialect) string {
+			return d.CreateTableCommand("t", []string{"a Int32", "b String"}, dialects.TableOptions{OrderBy: []string{"a"}})
+		},
+		expected: "create table t(a Int32,b String) engine = MergeTree order by (a) settings allow_nullable_key = 1",
+	},
+	{
+		name:    "oracle insert",
+		dialect: "oracle",
+		command: func(d dialects.Dialect) string {
+			batchStarter, rowStarter, batchEnder := d.InsertSyntax("t", "a,b")
+			return batchStarter + "1,2)" + rowStarter + "3,4)" + batchEnder
+		},
+		expected: "insert all into t (a,b) values (1,2) into t (a,b) values (3,4) select 1 from dual",
+	},
+	{
+		name:    "postgresql add foreign key",
+		dialect: "postgresql",
+		command: func(d dialects.Dialect) string {
+			command, _ := d.AddForeignKeyCommand("public.orders", "orders_fk", []string{"customer_id"}, "public.customers", []string{"id"})
+			return command
+		},
+		expected: "alter table public.orders add constraint orders_fk foreign key (customer_id) references public.customers (id)",
+	},
+	{
+		name:    "sqlite add foreign key",
+		dialect: "sqlite",
+		command: func(d dialects.Dialect) string {
+			command, ok := d.AddForeignKeyCommand("orders", "", []string{"customer_id"}, "customers", []string{"id"})
+			return fmt.Sprint(command, ok)
+		},
+		expected: "false",
+	},
+	{
+		name:    "mssql check values",
+		dialect: "mssql",
+		command: func(d dialects.Dialect) string {
+			check, _ := d.CheckValuesConstraint("size", []string{"small", "it's"})
+			return check
+		},
+		expected: " check (size in ('small','it''s'))",
+	},
+	{
+		name:    "snowflake check values",
+		dialect: "snowflake",
+		command: func(d dialects.Dialect) string {
+			check, ok := d.CheckValuesConstraint("size", []string{"small"})
+			return fmt.Sprint(check, ok)
+		},
+		expected: "false",
+	},
+}
+
+func TestDialects(t *testing.T) {
+	for _, tt := range dialectTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dialect, ok := dialects.Get(tt.dialect)
+			if !ok {
+				t.Fatalf("no dialect registered for %v", tt.dialect)
+			}
+			result := tt.command(dialect)
+			if result != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, result)
+			}
+		})
+	}
+}
+
+var validateTypeMappingsTests = []struct {
+	name     string
+	mappings map[string]string
+	expected []string
+}{
+	{name: "valid", mappings: map[string]string{"SQL_WVARCHAR": "varchar({{length}})", "jsonb": "jsonb", "SQL_DECIMAL": "numeric({{ precision }},{{scale}})"}, expected: []string{}},
+	{name: "unknown type", mappings: map[string]string{"SQL_NOPE": "text"}, expected: []string{"unknown column type SQL_NOPE"}},
+	{name: "unknown placeholder", mappings: map[string]string{"SQL_DOUBLE": "float({{size}})"}, expected: []string{"unknown placeholder {{size}} in template for SQL_DOUBLE"}},
+	{name: "empty template", mappings: map[string]string{"SQL_DOUBLE": " "}, expected: []string{"empty template for SQL_DOUBLE"}},
+}
+
+func TestValidateTypeMappings(t *testing.T) {
+	for _, tt := range validateTypeMappingsTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			problems := dialects.ValidateTypeMappings(tt.mappings)
+			if !reflect.DeepEqual(problems, tt.expected) {
+				t.Fatalf("\nwanted:\n%q\n\ngot:\n%q", tt.expected, problems)
+			}
+		})
+	}
+}
+
+var serverVersionTests = []struct {
+	name     string
+	dialect  string
+	version  dialects.ServerVersion
+	table    string
+	expected string
+}{
+	{name: "mssql 2014", dialect: "mssql", version: dialects.ServerVersion{Product: "Microsoft SQL Server", Version: "12.00.6024"}, expected: "if object_id('dbo.t', 'U') is not null drop table dbo.t"},
+	{name: "mssql 2014 quote", dialect: "mssql", version: dialects.ServerVersion{Product: "Microsoft SQL Server", Version: "12.00.6024"}, table: "dbo.o'brien", expected: "if object_id('dbo.o''brien', 'U') is not null drop table dbo.o'brien"},
+	{name: "mssql 2019", dialect: "mssql", version: dialects.ServerVersion{Product: "Microsoft SQL Server", Version: "15.00.2000"}, expected: "drop table if exists dbo.t"},
+	{name: "mssql unknown", dialect: "mssql", version: dialects.ServerVersion{}, expected: "drop table if exists dbo.t"},
+	{name: "oracle 21", dialect: "oracle", version: dialects.ServerVersion{Product: "Oracle", Version: "21.00.0000"}, expected: "drop table dbo.t"},
+	{name: "oracle 23", dialect: "oracle", version: dialects.ServerVersion{Product: "Oracle", Version: "23.00.0000"}, expected: "drop table if exists dbo.t"},
+}
+
+func TestForServerVersion(t *testing.T) {
+	for _, tt := range serverVersionTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dialect, ok := dialects.Get(tt.dialect)
+			if !ok {
+				t.Fatalf("no dialect registered for %v", tt.dialect)
+			}
+			table := tt.table
+			if table == "" {
+				table = "dbo.t"
+			}
+			result := dialects.ForServerVersion(dialect, tt.version).DropTableCommand(table)
+			if result != tt.expected {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expected, result)
+			}
+		})
+	}
+}
+
+// unreachableConnector fails every connection, like a target that's down.
+type unreachableConnector struct{}
+
+func (unreachableConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (unreachableConnector) Driver() driver.Driver { return nil }
+
+func TestForTargetWithoutVersion(t *testing.T) {
+	db := sql.OpenDB(unreachableConnector{})
+	defer db.Close()
+	for _, tt := range []struct{ dialect, expected string }{
+		{dialect: "mssql", expected: "drop table if exists dbo.t"},
+		{dialect: "oracle", expected: "drop table dbo.t"},
+		{dialect: "postgresql", expected: "drop table if exists dbo.t"},
+	} {
+		dialect, ok := dialects.Get(tt.dialect)
+		if !ok {
+			t.Fatalf("no dialect registered for %v", tt.dialect)
+		}
+		result := dialects.ForTarget(context.Background(), dialect, db).DropTableCommand("dbo.t")
+		if result != tt.expected {
+			t.Fatalf("%v:\nwanted:\n%v\n\ngot:\n%v", tt.dialect, tt.expected, result)
+		}
+	}
+}
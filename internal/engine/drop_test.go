@@ -39,6 +39,27 @@ var dropTests = []setupTest{
 		testQuery:   `drop table if exists wide_table;`,
 		expectedErr: "Stmt did not create a result set",
 	},
+	// Oracle
+	{
+		name:        "oracle wide_table drop",
+		source:      oracleTestSource,
+		testQuery:   `begin execute immediate 'drop table wide_table'; exception when others then if sqlcode != -942 then raise; end if; end;`,
+		expectedErr: "Stmt did not create a result set",
+	},
+	// SQLite
+	{
+		name:        "sqlite wide_table drop",
+		source:      sqliteTestSource,
+		testQuery:   `drop table if exists wide_table;`,
+		expectedErr: "Stmt did not create a result set",
+	},
+	// DuckDB
+	{
+		name:        "duckdb wide_table drop",
+		source:      duckdbTestSource,
+		testQuery:   `drop table if exists wide_table;`,
+		expectedErr: "Stmt did not create a result set",
+	},
 }
 
 func TestDrop(t *testing.T) {
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/sqlpipe/odbc"
+)
+
+func TestFetchSize(t *testing.T) {
+	_, err := odbc.NewConnector(postgresqlTestSource.Dsn() + ";FetchSize=abc")
+	if err == nil {
+		t.Fatalf("wanted an error for an invalid FetchSize")
+	}
+
+	query := `select
+		i,
+		'row ' || i as name,
+		case when i % 3 = 0 then null else i * 1.5 end as amount,
+		case when i % 2 = 0 then repeat('x', 2000) else null end as long_text
+	from generate_series(1, 250) i`
+
+	tests := []struct {
+		name      string
+		dsnSuffix string
+		fetchSize int
+	}{
+		{"one row at a time", "", 0},
+		{"fetch size from dsn", ";FetchSize=7", 7},
+		{"fetch size from connector", "", 100},
+		{"fetch size larger than result", ";FetchSize=1000", 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector, err := odbc.NewConnector(postgresqlTestSource.Dsn() + tt.dsnSuffix)
+			if err != nil {
+				t.Fatalf("error opening connector: %v", err)
+			}
+			if connector.FetchSize == 0 {
+				connector.FetchSize = tt.fetchSize
+			}
+			if connector.FetchSize != tt.fetchSize {
+				t.Fatalf("wanted fetch size %v, got %v", tt.fetchSize, connector.FetchSize)
+			}
+			db := sql.OpenDB(connector)
+			defer db.Close()
+
+			// the long text column can't be bound, so only the first query
+			// fetches row arrays
+			for _, q := range []string{"select i, name, amount from (" + query + ") q", query} {
+				rows, err := db.QueryContext(context.Background(), q)
+				if err != nil {
+					t.Fatalf("error querying: %v", err)
+				}
+				want := 1
+				for rows.Next() {
+					var i int
+					var name string
+					var amount sql.NullFloat64
+					dest := []any{&i, &name, &amount}
+					var longText sql.NullString
+					if q == query {
+						dest = append(dest, &longText)
+					}
+					err = rows.Scan(dest...)
+					if err != nil {
+						t.Fatalf("error scanning: %v", err)
+					}
+					if i != want || name != fmt.Sprintf("row %v", want) || amount.Valid != (want%3 != 0) {
+						t.Fatalf("wanted row %v, got %v, %v, %v", want, i, name, amount)
+					}
+					if q == query && longText.Valid != (want%2 == 0) {
+						t.Fatalf("wanted long text to be valid %v in row %v", want%2 == 0, want)
+					}
+					want++
+				}
+				if err = rows.Err(); err != nil {
+					t.Fatalf("error reading rows: %v", err)
+				}
+				rows.Close()
+				if want != 251 {
+					t.Fatalf("wanted 250 rows, got %v", want-1)
+				}
+			}
+		})
+	}
+}
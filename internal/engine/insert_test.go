@@ -18,7 +18,7 @@ var insertTests = []setupTest{
 		testQuery:   `insert into wide_table(mybigint, mybit, mybitvarying, myboolean, mybox, mybytea, mychar, myvarchar, mycidr, mycircle, mydate, mydoubleprecision, myinet, myinteger, myinterval, myjson, myjsonb, myline, mylseg, mymacaddr, mymoney , mynumeric, mypath, mypg_lsn, mypoint, mypolygon, myreal, mysmallint, mytext, mytime, mytimetz, mytimestamp, mytimestamptz, mytsquery, mytsvector, myuuid, myxml) values (6514798382812790784, B'10001', B'1001', true, '(8,9), (1,3)', '\xAAAABBBB', 'abc', '"my"varch''ar,123@gmail.com', '192.168.100.128/25', '(( 1 , 5 ), 5)', '2014-01-10 20:14:54.140332'::date, 529.56218983375436, '192.168.100.128', 745910651, (timestamptz '2014-01-20 20:00:00 PST' - timestamptz '2014-01-10 10:00:00 PST'), '{"mykey": "this\"  ''is'' m,y val"}', '{"mykey": "this is my val"}', '{1, 5, 20}', '[(5, 4), (2, 1)]', '08:00:2b:01:02:03', '$35,244.33'::money, 449.82115, '[( 1, 4), (8, 7)]', '16/B374D848'::pg_lsn, '(5, 7)', '((5, 8), (6, 10), (7, 20))', 9673.1094, 24345, 'myte",xt123@gmail.com', '03:46:38.765594+05', '03:46:38.765594+05', '2014-01-10 10:05:04 PST', '2014-01-10 10:05:04 PST', 'fat & rat'::tsquery, 'a fat cat sat on a mat and ate a fat rat'::tsvector, 'A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11'::uuid, '<foo>bar</foo>'),(null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null, null);`,
 		expectedErr: "Stmt did not create a result set",
 		checkQuery:  "select * from wide_table",
-		checkResult: "      mybigint       | mybit | mybitvarying | myboolean |    mybox    | mybytea | mychar |         myvarchar          |       mycidr       | mycircle  |        mydate        | mydoubleprecision |     myinet      | myinteger |    myinterval    |              myjson               |           myjsonb           |  myline  |    mylseg     |     mymacaddr     | mymoney  | mynumeric |    mypath     |  mypg_lsn   | mypoint |       mypolygon       |  myreal  | mysmallint |        mytext         |        mytime        |      mytimetz      |     mytimestamp      |    mytimestamptz     |   mytsquery   |                     mytsvector                     |                myuuid                |     myxml      \n---------------------+-------+--------------+-----------+-------------+---------+--------+----------------------------+--------------------+-----------+----------------------+-------------------+-----------------+-----------+------------------+-----------------------------------+-----------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+----------------------+--------------------+----------------------+----------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6514798382812790784 |  true |         1001 |         1 | (8,9),(1,3) |    \xaa\xaa\xbb\xbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10T00:00:00Z | 529.5621898337544 | 192.168.100.128 | 745910651 | 10 days 10:00:00 | {\"mykey\": \"this\\\"  'is' m,y val\"} | {\"mykey\": \"this is my val\"} | {1,5,20} | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 0001-01-01T03:46:38Z | 03:46:38.765594+05 | 2014-01-10T10:05:04Z | 2014-01-10T18:05:04Z | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                     |       |              |           |             |         |        |                            |                    |           |                      |                   |                 |           |                  |                                   |                             |          |               |                   |          |           |               |             |         |                       |          |            |                       |                      |                    |                      |                      |               |                                                    |                                      |                \n(2 rows)",
+		checkResult: "      mybigint       | mybit | mybitvarying | myboolean |    mybox    | mybytea | mychar |         myvarchar          |       mycidr       | mycircle  |        mydate        | mydoubleprecision |     myinet      | myinteger |    myinterval    |              myjson               |           myjsonb           |  myline  |    mylseg     |     mymacaddr     | mymoney  | mynumeric |    mypath     |  mypg_lsn   | mypoint |       mypolygon       |  myreal  | mysmallint |        mytext         |        mytime        |      mytimetz      |     mytimestamp      |    mytimestamptz     |   mytsquery   |                     mytsvector                     |                myuuid                |     myxml      \n---------------------+-------+--------------+-----------+-------------+---------+--------+----------------------------+--------------------+-----------+----------------------+-------------------+-----------------+-----------+------------------+-----------------------------------+-----------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+----------------------+--------------------+----------------------+----------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6514798382812790784 | 10001 |         1001 |         1 | (8,9),(1,3) |    \xaa\xaa\xbb\xbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10T00:00:00Z | 529.5621898337544 | 192.168.100.128 | 745910651 | 10 days 10:00:00 | {\"mykey\": \"this\\\"  'is' m,y val\"} | {\"mykey\": \"this is my val\"} | {1,5,20} | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 0001-01-01T03:46:38Z | 03:46:38.765594+05 | 2014-01-10T10:05:04Z | 2014-01-10T18:05:04Z | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                     |       |              |           |             |         |        |                            |                    |           |                      |                   |                 |           |                  |                                   |                             |          |               |                   |          |           |               |             |         |                       |          |            |                       |                      |                    |                      |                      |               |                                                    |                                      |                \n(2 rows)",
 	},
 	// MSSQL
 	{
@@ -47,6 +47,33 @@ var insertTests = []setupTest{
 		checkQuery:  "select * from wide_table",
 		checkResult: " MYNUMBER | MYINT | MYFLOAT |              MYVARCHAR              | MYBINARY | MYBOOLEAN |        MYDATE        |        MYTIME        |       MYTIMESTAMP_LTZ       |       MYTIMESTAMP_NTZ       |       MYTIMESTAMP_TZ        |              MYVARIANT              |                  MYOBJECT                  |                                             MYARRAY                                              |                             MYGEOGRAPHY                              \n----------+-------+---------+-------------------------------------+----------+-----------+----------------------+----------------------+-----------------------------+-----------------------------+-----------------------------+-------------------------------------+--------------------------------------------+--------------------------------------------------------------------------------------------------+----------------------------------------------------------------------\n     25.5 |    22 |    42.5 | hellooooo h'er\"es ,my varchar value |       \x00\x11 |      true | 2000-10-15T00:00:00Z | 0001-01-01T23:54:01Z | 2000-10-16T06:54:01.345673Z | 2000-10-15T23:54:01.345673Z | 2000-10-15T22:54:01.345673Z | {\n  \"mykey\": \"this is \\\"my' v,al\"\n} | {\n  \"key3\": \"value3\",\n  \"key4\": \"value4\"\n} | [\n  true,\n  1,\n  -1.200000000000000e-03,\n  \"Abc\",\n  [\n    \"x\",\n    \"y\"\n  ],\n  {\n    \"a\": 1\n  }\n] | {\n  \"coordinates\": [\n    -122.35,\n    37.55\n  ],\n  \"type\": \"Point\"\n} \n          |       |         |                                     |          |           |                      |                      |                             |                             |                             |                                     |                                            |                                                                                                  |                                                                      \n(2 rows)",
 	},
+	// Oracle
+	{
+		name:        "oracle wide_table insert",
+		source:      oracleTestSource,
+		testQuery:   `insert all into wide_table (mynumber, myinteger, mybinary_float, mybinary_double, mychar, myvarchar2, mynvarchar2, myclob, myraw, myblob, mydate, mytimestamp, mytimestamptz) values (449.82115, 745910651, 9673.109, 529.5621898337544, 'abc', '"my"varch''ar,123@gmail.com', 'nvarchar', 'myc",lob123@gmail.com', hextoraw('aaaabbbb'), hextoraw('0011'), to_date('2014-01-10','YYYY-MM-DD'), to_timestamp('2014-01-10 10:05:04','YYYY-MM-DD HH24:MI:SS'), to_timestamp_tz('2014-01-10 10:05:04 -08:00','YYYY-MM-DD HH24:MI:SS TZH:TZM')) into wide_table (mynumber) values (null) select 1 from dual`,
+		expectedErr: "Stmt did not create a result set",
+		checkQuery:  "select * from wide_table",
+		checkResult: " MYNUMBER  | MYINTEGER | MYBINARY_FLOAT |  MYBINARY_DOUBLE  | MYCHAR |         MYVARCHAR2         | MYNVARCHAR2 |        MYCLOB         | MYRAW | MYBLOB |        MYDATE        |     MYTIMESTAMP      |    MYTIMESTAMPTZ     \n-----------+-----------+----------------+-------------------+--------+----------------------------+-------------+-----------------------+-------+--------+----------------------+----------------------+----------------------\n 449.82115 | 745910651 |       9673.109 | 529.5621898337544 |    abc | \"my\"varch'ar,123@gmail.com |    nvarchar | myc\",lob123@gmail.com |  \xaa\xaa\xbb\xbb |     \x00\x11 | 2014-01-10T00:00:00Z | 2014-01-10T10:05:04Z | 2014-01-10T10:05:04Z \n           |           |                |                   |        |                            |             |                       |       |        |                      |                      |                      \n(2 rows)",
+	},
+	// SQLite
+	{
+		name:        "sqlite wide_table insert",
+		source:      sqliteTestSource,
+		testQuery:   `insert into wide_table (myinteger, myreal, mynumeric, mytext, myblob) values (745910651, 529.5621898337544, 449.82115, 'myte",xt123@gmail.com', x'aaaabbbb'),(null, null, null, null, null);`,
+		expectedErr: "Stmt did not create a result set",
+		checkQuery:  "select * from wide_table",
+		checkResult: " myinteger |      myreal       | mynumeric |        mytext         | myblob \n-----------+-------------------+-----------+-----------------------+--------\n 745910651 | 529.5621898337544 | 449.82115 | myte\",xt123@gmail.com |   \xaa\xaa\xbb\xbb \n           |                   |           |                       |        \n(2 rows)",
+	},
+	// DuckDB
+	{
+		name:        "duckdb wide_table insert",
+		source:      duckdbTestSource,
+		testQuery:   `insert into wide_table (mybigint, mydouble, mydecimal, myvarchar, myblob, myboolean, mydate, mytimestamp, myuuid) values (6514798382812790784, 529.5621898337544, 449.82115, 'myte",xt123@gmail.com', '\xAA\xAA\xBB\xBB'::blob, true, '2014-01-10', '2014-01-10 10:05:04', 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11'),(null, null, null, null, null, null, null, null, null);`,
+		expectedErr: "Stmt did not create a result set",
+		checkQuery:  "select * from wide_table",
+		checkResult: "      mybigint       |     mydouble      | mydecimal |       myvarchar       | myblob | myboolean |        mydate        |     mytimestamp      |                myuuid                \n---------------------+-------------------+-----------+-----------------------+--------+-----------+----------------------+----------------------+--------------------------------------\n 6514798382812790784 | 529.5621898337544 | 449.82115 | myte\",xt123@gmail.com |   \xaa\xaa\xbb\xbb |      true | 2014-01-10T00:00:00Z | 2014-01-10T10:05:04Z | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 \n                     |                   |           |                       |        |           |                      |                      |                                      \n(2 rows)",
+	},
 }
 
 func TestInsert(t *testing.T) {
@@ -0,0 +1,197 @@
+package pools
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sqlpipe/odbc"
+)
+
+var ErrClosed = errors.New("connection pools are closed")
+
+type Config struct {
+	// MaxOpenConns and MaxIdleConns limit each pool, with 0 meaning no limit
+	// on open connections, as in database/sql
+	MaxOpenConns int
+	MaxIdleConns int
+	// MaxIdleTime closes connections that have sat idle in a pool that long
+	MaxIdleTime time.Duration
+	// PoolIdleTimeout closes whole pools that no request has used for that long
+	PoolIdleTimeout time.Duration
+	// FetchSize is the rows fetched per round trip for DSNs that don't set
+	// their own FetchSize
+	FetchSize int
+	// OnClose is called with each pool's *sql.DB once it is closed, so
+	// anything cached for it can be dropped
+	OnClose func(db *sql.DB)
+}
+
+// Manager shares one *sql.DB per DSN between requests, so connections are
+// reused instead of opened for each request.
+type Manager struct {
+	config Config
+	mu     sync.Mutex
+	pools  map[string]*pool
+	nextID int
+	closed bool
+	done   chan struct{}
+}
+
+type pool struct {
+	// id names the pool in Stats without giving away anything in its DSN
+	id       string
+	db       *sql.DB
+	users    int
+	lastUsed time.Time
+}
+
+// New creates a Manager, and starts evicting pools that sit unused for
+// longer than the config's PoolIdleTimeout.
+func New(config Config) *Manager {
+	m := &Manager{
+		config: config,
+		pools:  map[string]*pool{},
+		done:   make(chan struct{}),
+	}
+	if config.PoolIdleTimeout > 0 {
+		go m.evict()
+	}
+	return m
+}
+
+// Get returns the pool for dsn, opening and pinging it if there isn't one.
+// The pool stays open until release is called, and must not be closed by
+// the caller.
+//
+// Pinging a new pool connects to the server, so a bad DSN fails here. The
+// driver's ping on an open connection only checks SQL_ATTR_CONNECTION_DEAD,
+// though, so an existing pool whose server has gone away is only noticed by
+// the first query that uses it.
+func (m *Manager) Get(ctx context.Context, dsn string) (db *sql.DB, release func(), err error) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil, nil, ErrClosed
+	}
+	p, ok := m.pools[dsn]
+	if !ok {
+		connector, err := odbc.NewConnector(dsn)
+		if err != nil {
+			m.mu.Unlock()
+			return nil, nil, err
+		}
+		if connector.FetchSize == 0 {
+			connector.FetchSize = m.config.FetchSize
+		}
+		// formatters look columns up by their native type names first
+		connector.NativeTypeNames = true
+		db := sql.OpenDB(connector)
+		db.SetMaxOpenConns(m.config.MaxOpenConns)
+		db.SetMaxIdleConns(m.config.MaxIdleConns)
+		db.SetConnMaxIdleTime(m.config.MaxIdleTime)
+		m.nextID++
+		p = &pool{id: fmt.Sprintf("pool-%v", m.nextID), db: db}
+		m.pools[dsn] = p
+	}
+	p.users++
+	m.mu.Unlock()
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			p.users--
+			p.lastUsed = time.Now()
+		})
+	}
+
+	// with an idle connection in the pool, this doesn't reach the server
+	err = p.db.PingContext(ctx)
+	if err != nil {
+		release()
+		if !ok {
+			m.remove(dsn, p)
+		}
+		return nil, nil, err
+	}
+	return p.db, release, nil
+}
+
+// remove closes a pool that failed its first ping, unless another request
+// has started using it since.
+func (m *Manager) remove(dsn string, p *pool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pools[dsn] == p && p.users == 0 {
+		delete(m.pools, dsn)
+		m.close(p)
+	}
+}
+
+func (m *Manager) evict() {
+	ticker := time.NewTicker(m.config.PoolIdleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+		}
+		m.mu.Lock()
+		for dsn, p := range m.pools {
+			if p.users == 0 && time.Since(p.lastUsed) > m.config.PoolIdleTimeout {
+				delete(m.pools, dsn)
+				m.close(p)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+// Stats returns each pool's database/sql stats, keyed by an id that stays the
+// same for as long as the pool is open. DSNs aren't used, since even redacted
+// they name hosts and users, and ones differing only by password collide.
+func (m *Manager) Stats() map[string]sql.DBStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := map[string]sql.DBStats{}
+	for _, p := range m.pools {
+		stats[p.id] = p.db.Stats()
+	}
+	return stats
+}
+
+// Close closes every pool. Requests still using one see their queries fail,
+// so it is called once they have finished.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+	close(m.done)
+
+	var closeErr error
+	for dsn, p := range m.pools {
+		err := m.close(p)
+		if err != nil && closeErr == nil {
+			closeErr = fmt.Errorf("error closing connection pool: %v", err)
+		}
+		delete(m.pools, dsn)
+	}
+	return closeErr
+}
+
+func (m *Manager) close(p *pool) error {
+	err := p.db.Close()
+	if m.config.OnClose != nil {
+		m.config.OnClose(p.db)
+	}
+	return err
+}
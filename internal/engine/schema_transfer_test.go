@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers"
+)
+
+type schemaTransferTest struct {
+	name           string              // name of test
+	schemaTransfer data.SchemaTransfer // schema transfer to run
+	wantStatuses   map[string]string   // expected status of each target table
+}
+
+var schemaTransferTests = []schemaTransferTest{
+	{
+		name: "postgresql public to mssql dbo",
+		schemaTransfer: data.SchemaTransfer{
+			Source:             postgresqlTestSource,
+			Target:             mssqlTestTarget,
+			SourceSchema:       "public",
+			Include:            []string{"wide_table", "catalog_%"},
+			DropTargetTables:   true,
+			CreateTargetTables: true,
+			Concurrency:        2,
+			ContinueOnError:    true,
+		},
+		wantStatuses: map[string]string{"wide_table": "succeeded", "catalog_table": "succeeded"},
+	},
+	{
+		name: "postgresql public to mssql dbo with exclude",
+		schemaTransfer: data.SchemaTransfer{
+			Source:             postgresqlTestSource,
+			Target:             mssqlTestTarget,
+			SourceSchema:       "public",
+			Include:            []string{"wide_table", "catalog_%"},
+			Exclude:            []string{"wide%"},
+			DropTargetTables:   true,
+			CreateTargetTables: true,
+		},
+		wantStatuses: map[string]string{"catalog_table": "succeeded"},
+	},
+}
+
+func TestSchemaTransfers(t *testing.T) {
+	for _, tt := range schemaTransferTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			tt.schemaTransfer.Source.Db, err = openSource(tt.schemaTransfer.Source.OdbcDsn)
+			if err != nil {
+				t.Fatalf("unable to create schema transfer source db, err: %v", err)
+			}
+			defer tt.schemaTransfer.Source.Db.Close()
+
+			tt.schemaTransfer.Target.Db, err = sql.Open(
+				"odbc",
+				tt.schemaTransfer.Target.OdbcDsn,
+			)
+			if err != nil {
+				t.Fatalf("unable to create schema transfer target db, err: %v", err)
+			}
+			defer tt.schemaTransfer.Target.Db.Close()
+
+			report, err := transfers.RunSchemaTransfer(context.Background(), tt.schemaTransfer)
+			if err != nil {
+				t.Fatalf("unable to run schema transfer. err:\n\n%v\n", err)
+			}
+			if len(report.Tables) != len(tt.wantStatuses) {
+				t.Fatalf("wanted %v tables, got %+v", len(tt.wantStatuses), report.Tables)
+			}
+			for _, result := range report.Tables {
+				if result.Status != tt.wantStatuses[result.TargetTable] {
+					t.Fatalf("wanted %v to have status %v, got %+v", result.TargetTable, tt.wantStatuses[result.TargetTable], result)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaTransferRerun(t *testing.T) {
+	sourceDb, err := openSource(postgresqlTestSource.OdbcDsn)
+	if err != nil {
+		t.Fatalf("unable to create schema transfer source db, err: %v", err)
+	}
+	defer sourceDb.Close()
+	targetDb, err := sql.Open("odbc", mssqlTestTarget.OdbcDsn)
+	if err != nil {
+		t.Fatalf("unable to create schema transfer target db, err: %v", err)
+	}
+	defer targetDb.Close()
+
+	// two keys to the same table, one of them on two columns, so their
+	// columns interleave in the catalog's rows
+	_, err = sourceDb.Exec(`drop table if exists rerun_child;
+		drop table if exists rerun_parent;
+		create table rerun_parent(a int, b int, primary key (a, b), unique (b));
+		create table rerun_child(
+			id int primary key,
+			a int,
+			b int,
+			c int,
+			constraint rerun_child_ab_fk foreign key (a, b) references rerun_parent (a, b),
+			constraint rerun_child_c_fk foreign key (c) references rerun_parent (b)
+		);`)
+	if err != nil {
+		t.Fatalf("error creating source tables: %v", err)
+	}
+
+	schemaTransfer := data.SchemaTransfer{
+		Source:             postgresqlTestSource,
+		Target:             mssqlTestTarget,
+		SourceSchema:       "public",
+		Include:            []string{"rerun_%"},
+		DropTargetTables:   true,
+		CreateTargetTables: true,
+		CreateForeignKeys:  true,
+		ContinueOnError:    true,
+	}
+	schemaTransfer.Source.Db = sourceDb
+	schemaTransfer.Target.Db = targetDb
+
+	wantColumns := map[string][]string{"rerun_child_ab_fk": {"a", "b"}, "rerun_child_c_fk": {"c"}}
+	// the second run has to drop tables the first run's foreign keys refer to
+	for run := 1; run <= 2; run++ {
+		report, err := transfers.RunSchemaTransfer(context.Background(), schemaTransfer)
+		if err != nil {
+			t.Fatalf("unable to run schema transfer %v. err:\n\n%v\n", run, err)
+		}
+		for _, result := range report.Tables {
+			if result.Status != "succeeded" {
+				t.Fatalf("run %v: wanted %v to succeed, got %+v", run, result.TargetTable, result)
+			}
+		}
+		if len(report.ForeignKeys) != len(wantColumns) {
+			t.Fatalf("run %v: wanted %v foreign keys, got %+v", run, len(wantColumns), report.ForeignKeys)
+		}
+		for _, result := range report.ForeignKeys {
+			if _, ok := wantColumns[result.Name]; !ok || result.Status != "succeeded" {
+				t.Fatalf("run %v: wanted foreign key %v to succeed, got %+v", run, result.Name, result)
+			}
+		}
+	}
+
+	tables := []catalog.Table{{Schema: "public", Name: "rerun_child"}}
+	foreignKeys, err := catalog.ListForeignKeys(context.Background(), sourceDb, tables)
+	if err != nil {
+		t.Fatalf("error listing foreign keys: %v", err)
+	}
+	if len(foreignKeys) != len(wantColumns) {
+		t.Fatalf("wanted %v foreign keys, got %+v", len(wantColumns), foreignKeys)
+	}
+	for _, foreignKey := range foreignKeys {
+		if !reflect.DeepEqual(foreignKey.Columns, wantColumns[foreignKey.Name]) {
+			t.Fatalf("wanted %v to have columns %v, got %v", foreignKey.Name, wantColumns[foreignKey.Name], foreignKey.Columns)
+		}
+	}
+}
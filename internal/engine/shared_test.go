@@ -1,9 +1,11 @@
 package engine
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
 
+	"github.com/sqlpipe/odbc"
 	"github.com/sqlpipe/sqlpipe/internal/data"
 )
 
@@ -21,6 +23,27 @@ var (
 	snowflakeTestSource = data.Source{
 		OdbcDsn: fmt.Sprintf("DRIVER=Snowflake;Server=%v.snowflakecomputing.com;PWD=%v;UID=%v;database=testing;schema=public;", os.Getenv("SNOWFLAKE_ACCOUNT"), os.Getenv("SNOWFLAKE_PASSWORD"), os.Getenv("SNOWFLAKE_USER")),
 	}
+	oracleTestSource = data.Source{
+		OdbcDsn: "DRIVER=Oracle;DBQ=localhost:1521/XEPDB1;UID=system;PWD=Mypass123;",
+	}
+	sqliteTestSource = data.Source{
+		OdbcDsn: "DRIVER=SQLite3;Database=/tmp/sqlpipe.sqlite;",
+	}
+	duckdbTestSource = data.Source{
+		OdbcDsn: "DRIVER=DuckDB;Database=/tmp/sqlpipe.duckdb;",
+	}
+	clickhouseTestSource = data.Source{
+		OdbcDsn: "DRIVER=ClickHouse;Url=http://localhost:8123;Database=default;UID=default;PWD=Mypass123;",
+	}
+	mariadbTestSource = data.Source{
+		OdbcDsn: "DRIVER=MySQL;SERVER=localhost;PORT=3307;UID=root;database=mysql;PWD=Mypass123;",
+	}
+	cockroachdbTestSource = data.Source{
+		OdbcDsn: "Driver=PostgreSQL;Server=localhost;Port=26257;Database=defaultdb;Uid=root;Pwd=;sslmode=disable;",
+	}
+	redshiftTestSource = data.Source{
+		OdbcDsn: fmt.Sprintf("Driver=PostgreSQL;Server=%v;Port=5439;Database=dev;Uid=%v;Pwd=%v;", os.Getenv("REDSHIFT_HOST"), os.Getenv("REDSHIFT_USER"), os.Getenv("REDSHIFT_PASSWORD")),
+	}
 )
 
 type setupTest struct {
@@ -32,3 +55,14 @@ type setupTest struct {
 	expectedErr      string      // if an error is expected, this will be the expected error
 	expectedCheckErr string      // if an error is expected during check query, this will be the expected error
 }
+
+// openSource opens dsn the way connection pools do, with native column type
+// names, so transfers pick the same formatters as they do in sqlpipe.
+func openSource(dsn string) (*sql.DB, error) {
+	connector, err := odbc.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	connector.NativeTypeNames = true
+	return sql.OpenDB(connector), nil
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sqlpipe/odbc"
+)
+
+func TestTimeouts(t *testing.T) {
+	db, err := sql.Open("odbc", postgresqlTestSource.Dsn())
+	if err != nil {
+		t.Fatalf("error running sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	t.Run("query timeout", func(t *testing.T) {
+		ctx := odbc.WithQueryTimeout(context.Background(), time.Second)
+		start := time.Now()
+		_, err := db.ExecContext(ctx, "select pg_sleep(10)")
+		if err == nil || !strings.Contains(err.Error(), "{HYT00}") {
+			t.Fatalf("wanted a HYT00 timeout error, got %v", err)
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("query ran for %v despite its timeout", time.Since(start))
+		}
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		start := time.Now()
+		_, err := db.ExecContext(ctx, "select pg_sleep(10)")
+		if !errors.Is(err, context.DeadlineExceeded) && (err == nil || !strings.Contains(err.Error(), "{HYT00}")) {
+			t.Fatalf("wanted the deadline to stop the query, got %v", err)
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("query ran for %v despite the deadline", time.Since(start))
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(time.Second, cancel)
+		start := time.Now()
+		_, err := db.ExecContext(ctx, "select pg_sleep(10)")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("wanted context.Canceled, got %v", err)
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("query ran for %v after being cancelled", time.Since(start))
+		}
+	})
+}
@@ -3,9 +3,11 @@ package engine
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/shomali11/xsql"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/sqlpipe/sqlpipe/internal/data"
 	"github.com/sqlpipe/sqlpipe/internal/engine/transfers"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/dialects"
 )
 
 var (
@@ -35,6 +38,37 @@ var (
 		SystemType: "snowflake",
 		OdbcDsn:    fmt.Sprintf("DRIVER=Snowflake;Server=%v.snowflakecomputing.com;PWD=%v;UID=%v;database=testing;schema=public;", os.Getenv("SNOWFLAKE_ACCOUNT"), os.Getenv("SNOWFLAKE_PASSWORD"), os.Getenv("SNOWFLAKE_USER")),
 	}
+	oracleTestTarget = data.Target{
+		SystemType: "oracle",
+		OdbcDsn:    "DRIVER=Oracle;DBQ=localhost:1521/XEPDB1;UID=system;PWD=Mypass123;",
+		Schema:     "system",
+	}
+	sqliteTestTarget = data.Target{
+		SystemType: "sqlite",
+		OdbcDsn:    "DRIVER=SQLite3;Database=/tmp/sqlpipe.sqlite;",
+	}
+	duckdbTestTarget = data.Target{
+		SystemType: "duckdb",
+		OdbcDsn:    "DRIVER=DuckDB;Database=/tmp/sqlpipe.duckdb;",
+	}
+	clickhouseTestTarget = data.Target{
+		SystemType: "clickhouse",
+		OdbcDsn:    "DRIVER=ClickHouse;Url=http://localhost:8123;Database=default;UID=default;PWD=Mypass123;",
+	}
+	mariadbTestTarget = data.Target{
+		SystemType: "mariadb",
+		OdbcDsn:    "DRIVER=MySQL;SERVER=localhost;PORT=3307;UID=root;database=mysql;PWD=Mypass123;",
+	}
+	cockroachdbTestTarget = data.Target{
+		SystemType: "cockroachdb",
+		OdbcDsn:    "Driver=PostgreSQL;Server=localhost;Port=26257;Database=defaultdb;Uid=root;Pwd=;sslmode=disable;",
+		Schema:     "public",
+	}
+	redshiftTestTarget = data.Target{
+		SystemType: "redshift",
+		OdbcDsn:    fmt.Sprintf("Driver=PostgreSQL;Server=%v;Port=5439;Database=dev;Uid=%v;Pwd=%v;", os.Getenv("REDSHIFT_HOST"), os.Getenv("REDSHIFT_USER"), os.Getenv("REDSHIFT_PASSWORD")),
+		Schema:     "public",
+	}
 )
 
 type transferTest struct {
@@ -61,7 +95,59 @@ var transferTests = []transferTest{
 		targetCheckSource: postgresqlTestSource,
 		targetTable:       "postgresql_wide_table",
 		checkQuery:        "select * from postgresql_wide_table;",
-		checkResult:       "      mybigint       | mybit | mybitvarying | myboolean |    mybox    | mybytea  | mychar |         myvarchar          |       mycidr       | mycircle  |        mydate        | mydoubleprecision |     myinet      | myinteger |    myinterval    |              myjson               |           myjsonb           |  myline  |    mylseg     |     mymacaddr     | mymoney  | mynumeric |    mypath     |  mypg_lsn   | mypoint |       mypolygon       |  myreal  | mysmallint |        mytext         |        mytime        |      mytimetz      |     mytimestamp      |    mytimestamptz     |   mytsquery   |                     mytsvector                     |                myuuid                |     myxml      \n---------------------+-------+--------------+-----------+-------------+----------+--------+----------------------------+--------------------+-----------+----------------------+-------------------+-----------------+-----------+------------------+-----------------------------------+-----------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+----------------------+--------------------+----------------------+----------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6514798382812790784 |     1 |         1001 |         1 | (8,9),(1,3) | aaaabbbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10T00:00:00Z | 529.5621898337544 | 192.168.100.128 | 745910651 | 10 days 10:00:00 | (\"mykey\": \"this\\\"  'is' m,y val\") | (\"mykey\": \"this is my val\") | (1,5,20) | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 0001-01-01T03:46:38Z | 03:46:38.765594+05 | 2014-01-10T10:05:04Z | 2014-01-10T18:05:04Z | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                     |       |              |           |             |          |        |                            |                    |           |                      |                   |                 |           |                  |                                   |                             |          |               |                   |          |           |               |             |         |                       |          |            |                       |                      |                    |                      |                      |               |                                                    |                                      |                \n(2 rows)",
+		checkResult:       "      mybigint       | mybit | mybitvarying | myboolean |    mybox    | mybytea  | mychar |         myvarchar          |       mycidr       | mycircle  |        mydate        | mydoubleprecision |     myinet      | myinteger |    myinterval    |              myjson               |           myjsonb           |  myline  |    mylseg     |     mymacaddr     | mymoney  | mynumeric |    mypath     |  mypg_lsn   | mypoint |       mypolygon       |  myreal  | mysmallint |        mytext         |        mytime        |      mytimetz      |     mytimestamp      |    mytimestamptz     |   mytsquery   |                     mytsvector                     |                myuuid                |     myxml      \n---------------------+-------+--------------+-----------+-------------+----------+--------+----------------------------+--------------------+-----------+----------------------+-------------------+-----------------+-----------+------------------+-----------------------------------+-----------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+----------------------+--------------------+----------------------+----------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6514798382812790784 | 10001 |         1001 |         1 | (8,9),(1,3) | aaaabbbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10T00:00:00Z | 529.5621898337544 | 192.168.100.128 | 745910651 | 10 days 10:00:00 | {\"mykey\": \"this\\\"  'is' m,y val\"} | {\"mykey\": \"this is my val\"} | (1,5,20) | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 0001-01-01T03:46:38Z | 03:46:38.765594+05 | 2014-01-10T10:05:04Z | 2014-01-10T18:05:04Z | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                     |       |              |           |             |          |        |                            |                    |           |                      |                   |                 |           |                  |                                   |                             |          |               |                   |          |           |               |             |         |                       |          |            |                       |                      |                    |                      |                      |               |                                                    |                                      |                \n(2 rows)",
+	},
+	{
+		name: "postgresql wide_table source_table to postgresql",
+		transfer: data.Transfer{
+			Source: postgresqlTestSource,
+			Target: postgresqlTestTarget,
+			SourceTable: &data.SourceTable{
+				Schema:  "public",
+				Table:   "wide_table",
+				Columns: []string{"mybigint", "mytext"},
+				Where:   []data.Condition{{Column: "mybigint", Operator: "is not null"}},
+			},
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+		},
+		targetCheckSource: postgresqlTestSource,
+		targetTable:       "postgresql_source_table",
+		checkQuery:        "select * from postgresql_source_table;",
+		checkResult:       "      mybigint       |        mytext         \n---------------------+-----------------------\n 6514798382812790784 | myte\",xt123@gmail.com \n(1 row)",
+	},
+	{
+		name: "postgresql wide_table to postgresql with parameters",
+		transfer: data.Transfer{
+			Source: postgresqlTestSource,
+			Target: postgresqlTestTarget,
+			SourceTable: &data.SourceTable{
+				Schema:  "public",
+				Table:   "wide_table",
+				Columns: []string{"mybigint", "mytext"},
+				Where:   []data.Condition{{Column: "mybigint", Operator: "is not null"}},
+			},
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+			InsertMethod:      "parameters",
+		},
+		targetCheckSource: postgresqlTestSource,
+		targetTable:       "postgresql_parameters",
+		checkQuery:        "select * from postgresql_parameters;",
+		checkResult:       "      mybigint       |        mytext         \n---------------------+-----------------------\n 6514798382812790784 | myte\",xt123@gmail.com \n(1 row)",
+	},
+	{
+		name: "postgresql wide_table to mysql with parameters",
+		transfer: data.Transfer{
+			Source:            postgresqlTestSource,
+			Target:            mysqlTestTarget,
+			Query:             "select * from wide_table",
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+			InsertMethod:      "parameters",
+		},
+		targetTable: "postgresql_parameters",
+		expectedErr: "column mybit must be converted for mysql targets, so it can't be inserted with parameters",
 	},
 	{
 		name: "postgresql wide_table to mssql",
@@ -75,7 +161,7 @@ var transferTests = []transferTest{
 		targetCheckSource: mssqlTestSource,
 		targetTable:       "postgresql_wide_table",
 		checkQuery:        "select * from postgresql_wide_table;",
-		checkResult:       "       mybigint        | mybit | mybitvarying | myboolean |    mybox    | mybytea  | mychar |         myvarchar          |       mycidr       | mycircle  |   mydate   | mydoubleprecision |     myinet      | myinteger |    myinterval    |              myjson               |           myjsonb           |  myline  |    mylseg     |     mymacaddr     | mymoney  | mynumeric |    mypath     |  mypg_lsn   | mypoint |       mypolygon       |  myreal  | mysmallint |        mytext         |      mytime      |      mytimetz      |         mytimestamp         |        mytimestamptz        |   mytsquery   |                     mytsvector                     |                myuuid                |     myxml      \n-----------------------+-------+--------------+-----------+-------------+----------+--------+----------------------------+--------------------+-----------+------------+-------------------+-----------------+-----------+------------------+-----------------------------------+-----------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+------------------+--------------------+-----------------------------+-----------------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6.514798382812791e+18 |  true |         1001 |         1 | (8,9),(1,3) | aaaabbbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10 | 529.5621898337544 | 192.168.100.128 | 745910651 | 10 days 10:00:00 | (\"mykey\": \"this\\\"  'is' m,y val\") | (\"mykey\": \"this is my val\") | (1,5,20) | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 03:46:38.0000000 | 03:46:38.765594+05 | 2014-01-10 10:05:04.0000000 | 2014-01-10 18:05:04.0000000 | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                       |       |              |           |             |          |        |                            |                    |           |            |                   |                 |           |                  |                                   |                             |          |               |                   |          |           |               |             |         |                       |          |            |                       |                  |                    |                             |                             |               |                                                    |                                      |                \n(2 rows)",
+		checkResult:       "       mybigint        | mybit | mybitvarying | myboolean |    mybox    | mybytea  | mychar |         myvarchar          |       mycidr       | mycircle  |   mydate   | mydoubleprecision |     myinet      | myinteger | myinterval |              myjson               |           myjsonb           |  myline  |    mylseg     |     mymacaddr     | mymoney  | mynumeric |    mypath     |  mypg_lsn   | mypoint |       mypolygon       |  myreal  | mysmallint |        mytext         |      mytime      |      mytimetz      |         mytimestamp         |           mytimestamptz            |   mytsquery   |                     mytsvector                     |                myuuid                |     myxml      \n-----------------------+-------+--------------+-----------+-------------+----------+--------+----------------------------+--------------------+-----------+------------+-------------------+-----------------+-----------+------------+-----------------------------------+-----------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+------------------+--------------------+-----------------------------+------------------------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6.514798382812791e+18 |     \x88 |            \x90 |         1 | (8,9),(1,3) | aaaabbbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10 | 529.5621898337544 | 192.168.100.128 | 745910651 |   P10DT10H | {\"mykey\": \"this\\\"  'is' m,y val\"} | {\"mykey\": \"this is my val\"} | (1,5,20) | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 03:46:38.0000000 | 03:46:38.765594+05 | 2014-01-10 10:05:04.0000000 | 2014-01-10 18:05:04.0000000 +00:00 | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                       |       |              |           |             |          |        |                            |                    |           |            |                   |                 |           |            |                                   |                             |          |               |                   |          |           |               |             |         |                       |          |            |                       |                  |                    |                             |                                    |               |                                                    |                                      |                \n(2 rows)",
 	},
 	{
 		name: "postgresql wide_table to mysql",
@@ -89,7 +175,7 @@ var transferTests = []transferTest{
 		targetCheckSource: mysqlTestSource,
 		targetTable:       "postgresql_wide_table",
 		checkQuery:        "select * from postgresql_wide_table;",
-		checkResult:       "      mybigint       | mybit | mybitvarying | myboolean |    mybox    | mybytea  | mychar |         myvarchar          |       mycidr       | mycircle  |        mydate        | mydoubleprecision |     myinet      | myinteger |    myinterval    |              myjson              |           myjsonb           |  myline  |    mylseg     |     mymacaddr     | mymoney  | mynumeric |    mypath     |  mypg_lsn   | mypoint |       mypolygon       |  myreal  | mysmallint |        mytext         |        mytime        |      mytimetz      |     mytimestamp      |    mytimestamptz     |   mytsquery   |                     mytsvector                     |                myuuid                |     myxml      \n---------------------+-------+--------------+-----------+-------------+----------+--------+----------------------------+--------------------+-----------+----------------------+-------------------+-----------------+-----------+------------------+----------------------------------+-----------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+----------------------+--------------------+----------------------+----------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6514798382812790784 |     1 |         1001 |         1 | (8,9),(1,3) | aaaabbbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10T00:00:00Z | 529.5621898337544 | 192.168.100.128 | 745910651 | 10 days 10:00:00 | (\"mykey\": \"this\"  'is' m,y val\") | (\"mykey\": \"this is my val\") | (1,5,20) | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 0001-01-01T03:46:38Z | 03:46:38.765594+05 | 2014-01-10T10:05:04Z | 2014-01-10T18:05:04Z | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                     |       |              |           |             |          |        |                            |                    |           |                      |                   |                 |           |                  |                                  |                             |          |               |                   |          |           |               |             |         |                       |          |            |                       |                      |                    |                      |                      |               |                                                    |                                      |                \n(2 rows)",
+		checkResult:       "      mybigint       | mybit | mybitvarying | myboolean |    mybox    | mybytea  | mychar |         myvarchar          |       mycidr       | mycircle  |        mydate        | mydoubleprecision |     myinet      | myinteger | myinterval |              myjson               |           myjsonb           |  myline  |    mylseg     |     mymacaddr     | mymoney  | mynumeric |    mypath     |  mypg_lsn   | mypoint |       mypolygon       |  myreal  | mysmallint |        mytext         |        mytime        |      mytimetz      |     mytimestamp      |    mytimestamptz     |   mytsquery   |                     mytsvector                     |                myuuid                |     myxml      \n---------------------+-------+--------------+-----------+-------------+----------+--------+----------------------------+--------------------+-----------+----------------------+-------------------+-----------------+-----------+------------+-----------------------------------+-----------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+----------------------+--------------------+----------------------+----------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6514798382812790784 |     \x11 |            \t |         1 | (8,9),(1,3) | aaaabbbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10T00:00:00Z | 529.5621898337544 | 192.168.100.128 | 745910651 |   P10DT10H | {\"mykey\": \"this\\\"  'is' m,y val\"} | {\"mykey\": \"this is my val\"} | (1,5,20) | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 0001-01-01T03:46:38Z | 03:46:38.765594+05 | 2014-01-10T10:05:04Z | 2014-01-10T18:05:04Z | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                     |       |              |           |             |          |        |                            |                    |           |                      |                   |                 |           |            |                                   |                             |          |               |                   |          |           |               |             |         |                       |          |            |                       |                      |                    |                      |                      |               |                                                    |                                      |                \n(2 rows)",
 	},
 	{
 		name: "postgresql wide_table to snowflake",
@@ -103,7 +189,7 @@ var transferTests = []transferTest{
 		targetCheckSource: snowflakeTestSource,
 		targetTable:       "postgresql_wide_table",
 		checkQuery:        "select * from postgresql_wide_table;",
-		checkResult:       "       MYBIGINT        | MYBIT | MYBITVARYING | MYBOOLEAN |    MYBOX    | MYBYTEA | MYCHAR |         MYVARCHAR          |       MYCIDR       | MYCIRCLE  |        MYDATE        | MYDOUBLEPRECISION |     MYINET      |   MYINTEGER    |    MYINTERVAL    |              MYJSON              |           MYJSONB           |  MYLINE  |    MYLSEG     |     MYMACADDR     | MYMONEY  | MYNUMERIC |    MYPATH     |  MYPG_LSN   | MYPOINT |       MYPOLYGON       |  MYREAL  | MYSMALLINT |        MYTEXT         |        MYTIME        |      MYTIMETZ      |     MYTIMESTAMP      |    MYTIMESTAMPTZ     |   MYTSQUERY   |                     MYTSVECTOR                     |                MYUUID                |     MYXML      \n-----------------------+-------+--------------+-----------+-------------+---------+--------+----------------------------+--------------------+-----------+----------------------+-------------------+-----------------+----------------+------------------+----------------------------------+-----------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+----------------------+--------------------+----------------------+----------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6.514798382812791e+18 |  true |         1001 |         1 | (8,9),(1,3) |    \xaa\xaa\xbb\xbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10T00:00:00Z | 529.5621898337544 | 192.168.100.128 | 7.45910651e+08 | 10 days 10:00:00 | (\"mykey\": \"this\"  'is' m,y val\") | (\"mykey\": \"this is my val\") | (1,5,20) | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 0001-01-01T03:46:38Z | 03:46:38.765594+05 | 2014-01-10T10:05:04Z | 2014-01-10T18:05:04Z | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                       |       |              |           |             |         |        |                            |                    |           |                      |                   |                 |                |                  |                                  |                             |          |               |                   |          |           |               |             |         |                       |          |            |                       |                      |                    |                      |                      |               |                                                    |                                      |                \n(2 rows)",
+		checkResult:       "       MYBIGINT        | MYBIT | MYBITVARYING | MYBOOLEAN |    MYBOX    | MYBYTEA | MYCHAR |         MYVARCHAR          |       MYCIDR       | MYCIRCLE  |        MYDATE        | MYDOUBLEPRECISION |     MYINET      |   MYINTEGER    | MYINTERVAL |                MYJSON                 |             MYJSONB             |  MYLINE  |    MYLSEG     |     MYMACADDR     | MYMONEY  | MYNUMERIC |    MYPATH     |  MYPG_LSN   | MYPOINT |       MYPOLYGON       |  MYREAL  | MYSMALLINT |        MYTEXT         |        MYTIME        |      MYTIMETZ      |     MYTIMESTAMP      |    MYTIMESTAMPTZ     |   MYTSQUERY   |                     MYTSVECTOR                     |                MYUUID                |     MYXML      \n-----------------------+-------+--------------+-----------+-------------+---------+--------+----------------------------+--------------------+-----------+----------------------+-------------------+-----------------+----------------+------------+---------------------------------------+---------------------------------+----------+---------------+-------------------+----------+-----------+---------------+-------------+---------+-----------------------+----------+------------+-----------------------+----------------------+--------------------+----------------------+----------------------+---------------+----------------------------------------------------+--------------------------------------+----------------\n 6.514798382812791e+18 |     \x88 |            \x90 |         1 | (8,9),(1,3) |    \xaa\xaa\xbb\xbb |    abc | \"my\"varch'ar,123@gmail.com | 192.168.100.128/25 | <(1,5),5> | 2014-01-10T00:00:00Z | 529.5621898337544 | 192.168.100.128 | 7.45910651e+08 |   P10DT10H | {\n  \"mykey\": \"this\\\"  'is' m,y val\"\n} | {\n  \"mykey\": \"this is my val\"\n} | (1,5,20) | [(5,4),(2,1)] | 08:00:2b:01:02:03 | 35244.33 | 449.82115 | [(1,4),(8,7)] | 16/B374D848 |   (5,7) | ((5,8),(6,10),(7,20)) | 9673.109 |      24345 | myte\",xt123@gmail.com | 0001-01-01T03:46:38Z | 03:46:38.765594+05 | 2014-01-10T10:05:04Z | 2014-01-10T18:05:04Z | 'fat' & 'rat' | 'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat' | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 | <foo>bar</foo> \n                       |       |              |           |             |         |        |                            |                    |           |                      |                   |                 |                |            |                                       |                                 |          |               |                   |          |           |               |             |         |                       |          |            |                       |                      |                    |                      |                      |               |                                                    |                                      |                \n(2 rows)",
 	},
 	// MSSQL source
 	{
@@ -259,7 +345,7 @@ var transferTests = []transferTest{
 		targetCheckSource: mssqlTestSource,
 		targetTable:       "snowflake_wide_table",
 		checkQuery:        "select * from snowflake_wide_table;",
-		checkResult:       " MYNUMBER | MYINT | MYFLOAT |              MYVARCHAR              | MYBINARY | MYBOOLEAN |   MYDATE   |      MYTIME      |       MYTIMESTAMP_LTZ       |       MYTIMESTAMP_NTZ       |       MYTIMESTAMP_TZ        |              MYVARIANT              |                  MYOBJECT                  |                                             MYARRAY                                              |                             MYGEOGRAPHY                              \n----------+-------+---------+-------------------------------------+----------+-----------+------------+------------------+-----------------------------+-----------------------------+-----------------------------+-------------------------------------+--------------------------------------------+--------------------------------------------------------------------------------------------------+----------------------------------------------------------------------\n     25.5 |    22 |    42.5 | hellooooo h'er\"es ,my varchar value |     0011 |      true | 2000-10-15 | 23:54:01.0000000 | 2000-10-16 06:54:01.3456730 | 2000-10-15 23:54:01.3456730 | 2000-10-15 22:54:01.3456730 | (\n  \"mykey\": \"this is \\\"my' v,al\"\n) | (\n  \"key3\": \"value3\",\n  \"key4\": \"value4\"\n) | [\n  true,\n  1,\n  -1.200000000000000e-03,\n  \"Abc\",\n  [\n    \"x\",\n    \"y\"\n  ],\n  (\n    \"a\": 1\n  )\n] | (\n  \"coordinates\": [\n    -122.35,\n    37.55\n  ],\n  \"type\": \"Point\"\n) \n          |       |         |                                     |          |           |            |                  |                             |                             |                             |                                     |                                            |                                                                                                  |                                                                      \n(2 rows)",
+		checkResult:       " MYNUMBER | MYINT | MYFLOAT |              MYVARCHAR              | MYBINARY | MYBOOLEAN |   MYDATE   |      MYTIME      |       MYTIMESTAMP_LTZ       |       MYTIMESTAMP_NTZ       |           MYTIMESTAMP_TZ           |              MYVARIANT              |                  MYOBJECT                  |                                             MYARRAY                                              |                             MYGEOGRAPHY                              \n----------+-------+---------+-------------------------------------+----------+-----------+------------+------------------+-----------------------------+-----------------------------+------------------------------------+-------------------------------------+--------------------------------------------+--------------------------------------------------------------------------------------------------+----------------------------------------------------------------------\n     25.5 |    22 |    42.5 | hellooooo h'er\"es ,my varchar value |     0011 |      true | 2000-10-15 | 23:54:01.0000000 | 2000-10-16 06:54:01.3456730 | 2000-10-15 23:54:01.3456730 | 2000-10-15 22:54:01.3456730 +00:00 | (\n  \"mykey\": \"this is \\\"my' v,al\"\n) | (\n  \"key3\": \"value3\",\n  \"key4\": \"value4\"\n) | [\n  true,\n  1,\n  -1.200000000000000e-03,\n  \"Abc\",\n  [\n    \"x\",\n    \"y\"\n  ],\n  (\n    \"a\": 1\n  )\n] | (\n  \"coordinates\": [\n    -122.35,\n    37.55\n  ],\n  \"type\": \"Point\"\n) \n          |       |         |                                     |          |           |            |                  |                             |                             |                                    |                                     |                                            |                                                                                                  |                                                                      \n(2 rows)",
 	},
 	{
 		name: "snowflake wide_table to snowflake",
@@ -275,6 +361,127 @@ var transferTests = []transferTest{
 		checkQuery:        "select * from snowflake_wide_table;",
 		checkResult:       " MYNUMBER | MYINT | MYFLOAT |              MYVARCHAR              | MYBINARY | MYBOOLEAN |        MYDATE        |        MYTIME        |       MYTIMESTAMP_LTZ       |       MYTIMESTAMP_NTZ       |       MYTIMESTAMP_TZ        |             MYVARIANT              |                  MYOBJECT                  |                                             MYARRAY                                              |                             MYGEOGRAPHY                              \n----------+-------+---------+-------------------------------------+----------+-----------+----------------------+----------------------+-----------------------------+-----------------------------+-----------------------------+------------------------------------+--------------------------------------------+--------------------------------------------------------------------------------------------------+----------------------------------------------------------------------\n     25.5 |    22 |    42.5 | hellooooo h'er\"es ,my varchar value |       \x00\x11 |      true | 2000-10-15T00:00:00Z | 0001-01-01T23:54:01Z | 2000-10-16T06:54:01.345673Z | 2000-10-15T23:54:01.345673Z | 2000-10-15T22:54:01.345673Z | (\n  \"mykey\": \"this is \"my' v,al\"\n) | (\n  \"key3\": \"value3\",\n  \"key4\": \"value4\"\n) | [\n  true,\n  1,\n  -1.200000000000000e-03,\n  \"Abc\",\n  [\n    \"x\",\n    \"y\"\n  ],\n  (\n    \"a\": 1\n  )\n] | (\n  \"coordinates\": [\n    -122.35,\n    37.55\n  ],\n  \"type\": \"Point\"\n) \n          |       |         |                                     |          |           |                      |                      |                             |                             |                             |                                    |                                            |                                                                                                  |                                                                      \n(2 rows)",
 	},
+	// Oracle source
+	{
+		name: "oracle wide_table to postgresql",
+		transfer: data.Transfer{
+			Source:            oracleTestSource,
+			Target:            postgresqlTestTarget,
+			Query:             "select * from wide_table",
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+		},
+		targetCheckSource: postgresqlTestSource,
+		targetTable:       "oracle_wide_table",
+		checkQuery:        "select * from oracle_wide_table;",
+		checkResult:       " mynumber  | myinteger | mybinary_float |  mybinary_double  | mychar |         myvarchar2         | mynvarchar2 |        myclob         | myraw | myblob |        mydate        |     mytimestamp      |    mytimestamptz     \n-----------+-----------+----------------+-------------------+--------+----------------------------+-------------+-----------------------+-------+--------+----------------------+----------------------+----------------------\n 449.82115 | 745910651 |       9673.109 | 529.5621898337544 |    abc | \"my\"varch'ar,123@gmail.com |    nvarchar | myc\",lob123@gmail.com |  \xaa\xaa\xbb\xbb |     \x00\x11 | 2014-01-10T00:00:00Z | 2014-01-10T10:05:04Z | 2014-01-10T10:05:04Z \n           |           |                |                   |        |                            |             |                       |       |        |                      |                      |                      \n(2 rows)",
+	},
+	{
+		name: "oracle wide_table to oracle",
+		transfer: data.Transfer{
+			Source:            oracleTestSource,
+			Target:            oracleTestTarget,
+			Query:             "select * from wide_table",
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+		},
+		targetCheckSource: oracleTestSource,
+		targetTable:       "oracle_wide_table",
+		checkQuery:        "select * from oracle_wide_table",
+		checkResult:       " MYNUMBER  | MYINTEGER | MYBINARY_FLOAT |  MYBINARY_DOUBLE  | MYCHAR |         MYVARCHAR2         | MYNVARCHAR2 |        MYCLOB         | MYRAW | MYBLOB |        MYDATE        |     MYTIMESTAMP      |    MYTIMESTAMPTZ     \n-----------+-----------+----------------+-------------------+--------+----------------------------+-------------+-----------------------+-------+--------+----------------------+----------------------+----------------------\n 449.82115 | 745910651 |       9673.109 | 529.5621898337544 |    abc | \"my\"varch'ar,123@gmail.com |    nvarchar | myc\",lob123@gmail.com |  \xaa\xaa\xbb\xbb |     \x00\x11 | 2014-01-10T00:00:00Z | 2014-01-10T10:05:04Z | 2014-01-10T10:05:04Z \n           |           |                |                   |        |                            |             |                       |       |        |                      |                      |                      \n(2 rows)",
+	},
+	// SQLite source
+	{
+		name: "sqlite wide_table to duckdb",
+		transfer: data.Transfer{
+			Source:            sqliteTestSource,
+			Target:            duckdbTestTarget,
+			Query:             "select * from wide_table",
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+		},
+		targetCheckSource: duckdbTestSource,
+		targetTable:       "sqlite_wide_table",
+		checkQuery:        "select * from sqlite_wide_table;",
+		checkResult:       " myinteger |      myreal       | mynumeric |        mytext         | myblob \n-----------+-------------------+-----------+-----------------------+--------\n 745910651 | 529.5621898337544 | 449.82115 | myte\",xt123@gmail.com |   \xaa\xaa\xbb\xbb \n           |                   |           |                       |        \n(2 rows)",
+	},
+	// DuckDB source
+	{
+		name: "duckdb wide_table to sqlite",
+		transfer: data.Transfer{
+			Source:            duckdbTestSource,
+			Target:            sqliteTestTarget,
+			Query:             "select * from wide_table",
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+		},
+		targetCheckSource: sqliteTestSource,
+		targetTable:       "duckdb_wide_table",
+		checkQuery:        "select * from duckdb_wide_table;",
+		checkResult:       "      mybigint       |     mydouble      | mydecimal |       myvarchar       | myblob | myboolean |   mydate   |     mytimestamp     |                myuuid                \n---------------------+-------------------+-----------+-----------------------+--------+-----------+------------+---------------------+--------------------------------------\n 6514798382812790784 | 529.5621898337544 | 449.82115 | myte\",xt123@gmail.com |   \xaa\xaa\xbb\xbb |         1 | 2014-01-10 | 2014-01-10 10:05:04 | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 \n                     |                   |           |                       |        |           |            |                     |                                      \n(2 rows)",
+	},
+	{
+		name: "duckdb wide_table to clickhouse",
+		transfer: data.Transfer{
+			Source: duckdbTestSource,
+			Target: data.Target{
+				SystemType: clickhouseTestTarget.SystemType,
+				OdbcDsn:    clickhouseTestTarget.OdbcDsn,
+				OrderBy:    []string{"mybigint"},
+			},
+			Query:             "select * from wide_table",
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+		},
+		targetCheckSource: clickhouseTestSource,
+		targetTable:       "duckdb_wide_table",
+		checkQuery:        "select * from duckdb_wide_table order by mybigint",
+		checkResult:       "      mybigint       |     mydouble      | mydecimal |       myvarchar       | myblob | myboolean |        mydate        |     mytimestamp      |                myuuid                \n---------------------+-------------------+-----------+-----------------------+--------+-----------+----------------------+----------------------+--------------------------------------\n 6514798382812790784 | 529.5621898337544 | 449.82115 | myte\",xt123@gmail.com |   \xaa\xaa\xbb\xbb |      true | 2014-01-10T00:00:00Z | 2014-01-10T10:05:04Z | a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11 \n                     |                   |           |                       |        |           |                      |                      |                                      \n(2 rows)",
+	},
+	{
+		name: "mysql wide_table to mariadb",
+		transfer: data.Transfer{
+			Source:            mysqlTestSource,
+			Target:            mariadbTestTarget,
+			Query:             "select * from wide_table",
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+		},
+		targetCheckSource: mariadbTestSource,
+		targetTable:       "mysql_wide_table",
+		checkQuery:        "select mybigint, myyear, myenum, myset from mysql_wide_table where mybigint is not null;",
+		checkResult:       "   mybigint   | myyear |  myenum  |  myset  \n--------------+--------+----------+---------\n 392809438543 |   1905 | enumval1 | setval1 \n(1 row)",
+	},
+	{
+		name: "postgresql wide_table to cockroachdb",
+		transfer: data.Transfer{
+			Source:            postgresqlTestSource,
+			Target:            cockroachdbTestTarget,
+			Query:             "select * from wide_table",
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+		},
+		targetCheckSource: cockroachdbTestSource,
+		targetTable:       "postgresql_wide_table",
+		// cockroachdb has no xml, cidr or money types
+		checkQuery:  "select myxml, mycidr, mymoney from postgresql_wide_table where mybigint is not null;",
+		checkResult: "     myxml      |       mycidr       |  mymoney   \n----------------+--------------------+------------\n <foo>bar</foo> | 192.168.100.128/25 | 35244.3300 \n(1 row)",
+	},
+	{
+		name: "postgresql wide_table to redshift",
+		transfer: data.Transfer{
+			Source:            postgresqlTestSource,
+			Target:            redshiftTestTarget,
+			Query:             "select * from wide_table",
+			DropTargetTable:   true,
+			CreateTargetTable: true,
+		},
+		targetCheckSource: redshiftTestSource,
+		targetTable:       "postgresql_wide_table",
+		// redshift has no bytea or json types, so these are written as text
+		checkQuery:  "select mybytea, myjson, myjsonb from postgresql_wide_table where mybigint is not null;",
+		checkResult: " mybytea  |              myjson               |           myjsonb           \n----------+-----------------------------------+-----------------------------\n aaaabbbb | {\"mykey\": \"this\\\"  'is' m,y val\"} | {\"mykey\": \"this is my val\"} \n(1 row)",
+	},
 }
 
 func TestTransfers(t *testing.T) {
@@ -282,10 +489,7 @@ func TestTransfers(t *testing.T) {
 	var err error
 
 	for _, tt := range transferTests {
-		tt.transfer.Source.Db, err = sql.Open(
-			"odbc",
-			tt.transfer.Source.OdbcDsn,
-		)
+		tt.transfer.Source.Db, err = openSource(tt.transfer.Source.OdbcDsn)
 		if err != nil {
 			t.Fatalf("unable to create transfer source db, err: %v\n", err)
 		}
@@ -316,6 +520,12 @@ func TestTransfers(t *testing.T) {
 				tt.transfer,
 			)
 
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Fatalf("\nwanted error:\n%#v\n\ngot error:\n%#v\n", tt.expectedErr, err)
+				}
+				return
+			}
 			if err != nil {
 				t.Fatalf("unable to run transfer. err:\n\n%v\n", err)
 			}
@@ -338,3 +548,197 @@ func TestTransfers(t *testing.T) {
 		})
 	}
 }
+
+var buildSourceQueryTests = []struct {
+	name          string
+	sourceTable   data.SourceTable
+	quote         string
+	expectedQuery string
+	expectedArgs  []any
+}{
+	{
+		name:          "whole table",
+		sourceTable:   data.SourceTable{Table: "wide_table"},
+		quote:         `"`,
+		expectedQuery: `select * from "wide_table"`,
+	},
+	{
+		name:          "columns and schema",
+		sourceTable:   data.SourceTable{Schema: "dbo", Table: "my]table", Columns: []string{"a", "b"}},
+		quote:         "]",
+		expectedQuery: "select [a],[b] from [dbo].[my]]table]",
+	},
+	{
+		name:          "opening bracket",
+		sourceTable:   data.SourceTable{Table: "my[table]"},
+		quote:         "[",
+		expectedQuery: "select * from [my[table]]]",
+	},
+	{
+		name: "where",
+		sourceTable: data.SourceTable{Table: "t", Where: []data.Condition{
+			{Column: "a", Operator: ">=", Value: 5.0},
+			{Column: "b", Operator: "in", Value: []any{"x", "y"}},
+			{Column: "c", Operator: "is null"},
+		}},
+		quote:         "`",
+		expectedQuery: "select * from `t` where `a` >= ? and `b` in (?,?) and `c` is null",
+		expectedArgs:  []any{5.0, "x", "y"},
+	},
+	{
+		name:          "no quoting",
+		sourceTable:   data.SourceTable{Schema: "main", Table: "t"},
+		quote:         "",
+		expectedQuery: "select * from main.t",
+	},
+}
+
+func TestBuildSourceQuery(t *testing.T) {
+	for _, tt := range buildSourceQueryTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := transfers.BuildSourceQuery(tt.sourceTable, tt.quote)
+			if query != tt.expectedQuery {
+				t.Fatalf("\nwanted:\n%v\n\ngot:\n%v", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Fatalf("wanted args %v, got %v", tt.expectedArgs, args)
+			}
+		})
+	}
+}
+
+var batchFullTests = []struct {
+	name        string
+	checkType   string
+	checkNum    int
+	rowsRead    int
+	batchLength int
+	expected    bool
+}{
+	{name: "rows under limit", checkType: "rows", checkNum: 1000, rowsRead: 999, batchLength: 50000, expected: false},
+	{name: "rows at limit", checkType: "rows", checkNum: 1000, rowsRead: 2000, batchLength: 10, expected: true},
+	{name: "length under limit", checkType: "length", checkNum: 4000000, rowsRead: 1000, batchLength: 3999999, expected: false},
+	{name: "length at limit", checkType: "length", checkNum: 4000000, rowsRead: 7, batchLength: 4000000, expected: true},
+	// a modulo check would only send batches that land exactly on a multiple
+	{name: "length past limit", checkType: "length", checkNum: 4000000, rowsRead: 7, batchLength: 4000123, expected: true},
+}
+
+// TestRedshiftBatchPolicy checks that redshift batches are sent before they
+// reach its 16MB statement limit.
+func TestRedshiftBatchPolicy(t *testing.T) {
+	dialect, ok := dialects.Get("redshift")
+	if !ok {
+		t.Fatal("no redshift dialect")
+	}
+	checkType, checkNum := dialect.BatchPolicy()
+	if checkType != "length" || checkNum > 16000000 {
+		t.Fatalf("wanted a length limit under 16MB, got %v %v", checkType, checkNum)
+	}
+	if transfers.BatchFull(checkType, checkNum, 1, checkNum-1) {
+		t.Fatalf("wanted a batch of %v to not be full", checkNum-1)
+	}
+	if !transfers.BatchFull(checkType, checkNum, 1, checkNum) {
+		t.Fatalf("wanted a batch of %v to be full", checkNum)
+	}
+}
+
+func TestBatchFull(t *testing.T) {
+	for _, tt := range batchFullTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result := transfers.BatchFull(tt.checkType, tt.checkNum, tt.rowsRead, tt.batchLength)
+			if result != tt.expected {
+				t.Fatalf("wanted %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+var parameterFormatterTests = []struct {
+	name      string
+	dialect   string
+	colDbType string
+	converted bool
+}{
+	{name: "oracle text", dialect: "oracle", colDbType: "SQL_WVARCHAR:text", converted: true},
+	{name: "oracle number", dialect: "oracle", colDbType: "SQL_INTEGER:int4", converted: false},
+	{name: "mssql timestamptz", dialect: "mssql", colDbType: "SQL_TYPE_TIMESTAMP:timestamptz", converted: true},
+	{name: "mssql datetime2", dialect: "mssql", colDbType: "SQL_TYPE_TIMESTAMP:datetime2", converted: false},
+	{name: "redshift bit string", dialect: "redshift", colDbType: "SQL_VARCHAR:bit varying", converted: true},
+	{name: "redshift text", dialect: "redshift", colDbType: "SQL_VARCHAR:text", converted: false},
+	{name: "postgresql money", dialect: "postgresql", colDbType: "SQL_VARCHAR:money", converted: true},
+	{name: "mysql text array", dialect: "mysql", colDbType: "SQL_VARCHAR:_text", converted: true},
+	{name: "clickhouse interval", dialect: "clickhouse", colDbType: "SQL_VARCHAR:interval", converted: true},
+}
+
+// TestCheckParameterFormatters runs the check against each registered
+// dialect's own formatters.
+func TestCheckParameterFormatters(t *testing.T) {
+	for _, name := range dialects.Names() {
+		dialect, _ := dialects.Get(name)
+		err := transfers.CheckParameterFormatters(dialect, []string{"mybool"}, []string{"SQL_BIT:bool"})
+		if err == nil {
+			t.Fatalf("%v: wanted booleans to need converting", name)
+		}
+		err = transfers.CheckParameterFormatters(dialect, []string{"myint"}, []string{"SQL_INTEGER:int4"})
+		if err != nil {
+			t.Fatalf("%v: wanted integers to be inserted as they are, got %v", name, err)
+		}
+	}
+	for _, tt := range parameterFormatterTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dialect, ok := dialects.Get(tt.dialect)
+			if !ok {
+				t.Fatalf("no dialect registered for %v", tt.dialect)
+			}
+			err := transfers.CheckParameterFormatters(dialect, []string{"mycol"}, []string{tt.colDbType})
+			if converted := err != nil; converted != tt.converted {
+				t.Fatalf("wanted converted %v, got error %v", tt.converted, err)
+			}
+		})
+	}
+}
+
+var parameterBatchTests = []struct {
+	name     string
+	rows     []string
+	maxRows  int
+	maxBytes int
+	expected []int
+}{
+	{name: "row limit", rows: []string{"a", "b", "c", "d", "e"}, maxRows: 2, maxBytes: 100, expected: []int{2, 2, 1}},
+	// the third row would widen the batch to 3 rows of 42 bytes
+	{name: "flush before append", rows: []string{"a", "b", strings.Repeat("x", 20)}, maxRows: 10, maxBytes: 100, expected: []int{2, 1}},
+	// after the flush the long row still sets the width of the rows after it
+	{name: "width kept after flush", rows: []string{"a", "b", strings.Repeat("x", 20), "c", "d"}, maxRows: 10, maxBytes: 100, expected: []int{2, 2, 1}},
+	{name: "oversize single row", rows: []string{"a", strings.Repeat("x", 60), "b"}, maxRows: 10, maxBytes: 100, expected: []int{1, 1, 1}},
+}
+
+func TestParameterBatch(t *testing.T) {
+	for _, tt := range parameterBatchTests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			sent := []int{}
+			send := func(columns [][]driver.Value) error {
+				sent = append(sent, len(columns[0]))
+				return nil
+			}
+			batch := transfers.NewParameterBatch(1, tt.maxRows, tt.maxBytes)
+			for _, row := range tt.rows {
+				err := batch.Add([]driver.Value{row}, send)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := batch.Flush(send)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(sent, tt.expected) {
+				t.Fatalf("wanted batches of %v, got %v", tt.expected, sent)
+			}
+		})
+	}
+}
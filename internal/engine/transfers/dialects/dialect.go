@@ -0,0 +1,225 @@
+package dialects
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+// Dialect is everything RunTransfer needs to know to write to a kind of
+// target database.
+type Dialect interface {
+	Name() string
+	// SourceTypes lists the column types the dialect has create formatters for
+	SourceTypes() []string
+	// CreateFormatter maps a source column type to the target's column type,
+	// preferring the source's native type name and falling back to the
+	// generic ODBC type
+	CreateFormatter(colDbType string) (func(column *sql.ColumnType, terminator string) (string, error), bool)
+	// ValFormatter encodes a source value as a literal the target accepts,
+	// looked up the same way as CreateFormatter
+	ValFormatter(colDbType string) (func(value interface{}, terminator string) (string, error), bool)
+	// ConvertsValue reports whether ValFormatter writes the column's values in
+	// a different form from the one the source returns, such as money as a
+	// number or an empty Oracle string as null
+	ConvertsValue(colDbType string) bool
+	// SelectExpression wraps a column of values that can't be inserted as is
+	SelectExpression(colDbType string) (string, bool)
+	QuoteIdentifier(identifier string) string
+	DropTableCommand(table string) string
+	IsMissingTableError(err error) bool
+	CreateTableCommand(table string, columns []string, options TableOptions) string
+	TruncateTableCommand(table string) string
+	// CheckValuesConstraint limits a column to values, as a constraint added
+	// after its type. It returns false if the target doesn't enforce check
+	// constraints.
+	CheckValuesConstraint(column string, values []string) (string, bool)
+	// AddForeignKeyCommand returns false if the target can't add foreign keys
+	// to existing tables
+	AddForeignKeyCommand(table, name string, columns []string, referencedTable string, referencedColumns []string) (string, bool)
+	// InsertSyntax returns what starts a batch of inserts, what starts each
+	// following row, and what ends the batch
+	InsertSyntax(table, columns string) (batchStarter, rowStarter, batchEnder string)
+	// BatchPolicy returns whether batches are limited by "rows" or "length",
+	// and the limit
+	BatchPolicy() (checkType string, checkNum int)
+}
+
+// TableOptions holds target settings that only some dialects use when creating
+// tables.
+type TableOptions struct {
+	Engine  string
+	OrderBy []string
+}
+
+var registry = map[string]Dialect{}
+
+// Register makes a dialect available as a target system type.
+func Register(dialect Dialect) {
+	registry[dialect.Name()] = dialect
+}
+
+func Get(name string) (Dialect, bool) {
+	dialect, ok := registry[name]
+	return dialect, ok
+}
+
+// Names returns the registered system types in alphabetical order.
+func Names() []string {
+	names := []string{}
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sqlDialect is a dialect described entirely by its settings. Dialects that
+// need more embed it and override its methods.
+type sqlDialect struct {
+	name                 string
+	createFormatters     map[string]func(column *sql.ColumnType, terminator string) (string, error)
+	valFormatters        map[string]func(value interface{}, terminator string) (formattedValue string, err error)
+	convertedValues      map[string]bool
+	selectExpressions    map[string]string
+	quoteStart           string
+	quoteEnd             string
+	dropTableStarter     string
+	missingTableError    string
+	truncateTableStarter string
+	noForeignKeys        bool
+	noChecks             bool
+	batchCheckType       string
+	batchCheckNum        int
+}
+
+func (d sqlDialect) Name() string {
+	return d.name
+}
+
+func (d sqlDialect) SourceTypes() []string {
+	sourceTypes := []string{}
+	for sourceType := range d.createFormatters {
+		sourceTypes = append(sourceTypes, sourceType)
+	}
+	return sourceTypes
+}
+
+func (d sqlDialect) CreateFormatter(colDbType string) (func(column *sql.ColumnType, terminator string) (string, error), bool) {
+	return shared.Lookup(d.createFormatters, colDbType)
+}
+
+func (d sqlDialect) ValFormatter(colDbType string) (func(value interface{}, terminator string) (string, error), bool) {
+	return shared.Lookup(d.valFormatters, colDbType)
+}
+
+func (d sqlDialect) ConvertsValue(colDbType string) bool {
+	key, ok := shared.LookupKey(d.valFormatters, colDbType)
+	return ok && d.convertedValues[key]
+}
+
+func (d sqlDialect) SelectExpression(colDbType string) (string, bool) {
+	return shared.Lookup(d.selectExpressions, colDbType)
+}
+
+func (d sqlDialect) QuoteIdentifier(identifier string) string {
+	return d.quoteStart + strings.ReplaceAll(identifier, d.quoteEnd, d.quoteEnd+d.quoteEnd) + d.quoteEnd
+}
+
+func (d sqlDialect) DropTableCommand(table string) string {
+	return fmt.Sprintf("%v %v", d.dropTableStarter, table)
+}
+
+func (d sqlDialect) IsMissingTableError(err error) bool {
+	return d.missingTableError != "" && strings.Contains(err.Error(), d.missingTableError)
+}
+
+func (d sqlDialect) CreateTableCommand(table string, columns []string, options TableOptions) string {
+	return fmt.Sprintf("create table %v(%v)", table, strings.Join(columns, ","))
+}
+
+func (d sqlDialect) TruncateTableCommand(table string) string {
+	return fmt.Sprintf("%v %v", d.truncateTableStarter, table)
+}
+
+func (d sqlDialect) AddForeignKeyCommand(table, name string, columns []string, referencedTable string, referencedColumns []string) (string, bool) {
+	if d.noForeignKeys {
+		return "", false
+	}
+	constraint := ""
+	if name != "" {
+		constraint = fmt.Sprintf(" constraint %v", name)
+	}
+	return fmt.Sprintf("alter table %v add%v foreign key (%v) references %v (%v)", table, constraint, strings.Join(columns, ","), referencedTable, strings.Join(referencedColumns, ",")), true
+}
+
+func (d sqlDialect) CheckValuesConstraint(column string, values []string) (string, bool) {
+	if d.noChecks {
+		return "", false
+	}
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = fmt.Sprintf("'%v'", strings.ReplaceAll(value, "'", "''"))
+	}
+	return fmt.Sprintf(" check (%v in (%v))", column, strings.Join(literals, ",")), true
+}
+
+func (d sqlDialect) InsertSyntax(table, columns string) (batchStarter, rowStarter, batchEnder string) {
+	return fmt.Sprintf("insert into %v (%v) values (", table, columns), ",(", ""
+}
+
+func (d sqlDialect) BatchPolicy() (checkType string, checkNum int) {
+	return d.batchCheckType, d.batchCheckNum
+}
+
+// plainIdentifierRX matches names that every target reads the same way with
+// or without quotes, apart from folding their case.
+var plainIdentifierRX = regexp.MustCompile(`^([a-z_][a-z0-9_]*|[A-Z_][A-Z0-9_]*)$`)
+
+// reservedWords are keywords that at least one target won't take as a bare
+// column or table name.
+var reservedWords = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`
+		access add all alter and any array as asc audit authorization between
+		both by case cast char check cluster collate column comment compress
+		connect constraint create cross current current_date current_time
+		current_timestamp current_user date decimal default delete desc
+		distinct drop else end except exclusive exists false fetch file float
+		for foreign from full grant group having identified immediate in
+		increment index initial inner insert integer intersect interval into
+		is join key leading left level like limit lock long minus mode modify
+		natural not null number of offset on only option or order outer
+		primary prior public range raw references rename resource revoke
+		right row rowid rownum rows select session session_user set share
+		size smallint some start synonym sysdate table then timestamp to
+		trailing trigger true uid union unique update user using validate
+		values varchar varchar2 view when where window with`) {
+		reservedWords[word] = true
+	}
+}
+
+// Identifier quotes name for the dialect if it mixes upper and lower case,
+// has characters other than letters, digits and underscores, or is a
+// reserved word. Other names are left bare, so the target folds their case
+// as it would in any query written against it.
+func Identifier(dialect Dialect, name string) string {
+	if plainIdentifierRX.MatchString(name) && !reservedWords[strings.ToLower(name)] {
+		return name
+	}
+	return dialect.QuoteIdentifier(name)
+}
+
+// TableName is table qualified by schema, if there is one, with each part
+// written by Identifier.
+func TableName(dialect Dialect, schema, table string) string {
+	if schema == "" {
+		return Identifier(dialect, table)
+	}
+	return Identifier(dialect, schema) + "." + Identifier(dialect, table)
+}
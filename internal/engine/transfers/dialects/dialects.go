@@ -0,0 +1,210 @@
+package dialects
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+func init() {
+	for _, dialect := range []Dialect{
+		sqlDialect{
+			name:                 "postgresql",
+			createFormatters:     formatters.PostgresqlCreateFormatters,
+			valFormatters:        formatters.PostgresqlValFormatters,
+			convertedValues:      formatters.PostgresqlConvertedValues,
+			quoteStart:           `"`,
+			quoteEnd:             `"`,
+			dropTableStarter:     "drop table if exists",
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "length",
+			batchCheckNum:        10000000,
+		},
+		mssqlDialect{sqlDialect: sqlDialect{
+			name:                 "mssql",
+			createFormatters:     formatters.MssqlCreateFormatters,
+			valFormatters:        formatters.MssqlValFormatters,
+			convertedValues:      formatters.MssqlConvertedValues,
+			quoteStart:           "[",
+			quoteEnd:             "]",
+			dropTableStarter:     "drop table if exists",
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "rows",
+			batchCheckNum:        1000,
+		}},
+		sqlDialect{
+			name:                 "mysql",
+			createFormatters:     formatters.MysqlCreateFormatters,
+			valFormatters:        formatters.MysqlValFormatters,
+			convertedValues:      formatters.MysqlConvertedValues,
+			quoteStart:           "`",
+			quoteEnd:             "`",
+			dropTableStarter:     "drop table if exists",
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "length",
+			batchCheckNum:        4000000,
+		},
+		sqlDialect{
+			name:             "snowflake",
+			createFormatters: formatters.SnowflakeCreateFormatters,
+			valFormatters:    formatters.SnowflakeValFormatters,
+			convertedValues:  formatters.SnowflakeConvertedValues,
+			selectExpressions: map[string]string{
+				"json":           "parse_json(%v)",
+				"jsonb":          "parse_json(%v)",
+				"array":          "parse_json(%v)",
+				"SQL_SS_VARIANT": "to_variant(%v)",
+				"sql_variant":    "to_variant(%v)",
+			},
+			quoteStart:           `"`,
+			quoteEnd:             `"`,
+			dropTableStarter:     "drop table if exists",
+			noChecks:             true,
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "rows",
+			batchCheckNum:        3000,
+		},
+		oracleDialect{sqlDialect{
+			name:                 "oracle",
+			createFormatters:     formatters.OracleCreateFormatters,
+			valFormatters:        formatters.OracleValFormatters,
+			convertedValues:      formatters.OracleConvertedValues,
+			quoteStart:           `"`,
+			quoteEnd:             `"`,
+			dropTableStarter:     "drop table",
+			missingTableError:    "ORA-00942",
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "rows",
+			batchCheckNum:        500,
+		}},
+		sqlDialect{
+			name:                 "sqlite",
+			createFormatters:     formatters.SqliteCreateFormatters,
+			valFormatters:        formatters.SqliteValFormatters,
+			convertedValues:      formatters.SqliteConvertedValues,
+			quoteStart:           `"`,
+			quoteEnd:             `"`,
+			dropTableStarter:     "drop table if exists",
+			noForeignKeys:        true,
+			truncateTableStarter: "delete from",
+			batchCheckType:       "rows",
+			batchCheckNum:        500,
+		},
+		sqlDialect{
+			name:                 "duckdb",
+			createFormatters:     formatters.DuckdbCreateFormatters,
+			valFormatters:        formatters.DuckdbValFormatters,
+			convertedValues:      formatters.DuckdbConvertedValues,
+			quoteStart:           `"`,
+			quoteEnd:             `"`,
+			dropTableStarter:     "drop table if exists",
+			noForeignKeys:        true,
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "rows",
+			batchCheckNum:        5000,
+		},
+		clickhouseDialect{sqlDialect{
+			name:                 "clickhouse",
+			createFormatters:     formatters.ClickhouseCreateFormatters,
+			valFormatters:        formatters.ClickhouseValFormatters,
+			convertedValues:      formatters.ClickhouseConvertedValues,
+			quoteStart:           "`",
+			quoteEnd:             "`",
+			dropTableStarter:     "drop table if exists",
+			noForeignKeys:        true,
+			noChecks:             true,
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "rows",
+			batchCheckNum:        100000,
+		}},
+		sqlDialect{
+			name:                 "mariadb",
+			createFormatters:     formatters.MariadbCreateFormatters,
+			valFormatters:        formatters.MariadbValFormatters,
+			convertedValues:      formatters.MariadbConvertedValues,
+			quoteStart:           "`",
+			quoteEnd:             "`",
+			dropTableStarter:     "drop table if exists",
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "length",
+			batchCheckNum:        4000000,
+		},
+		sqlDialect{
+			name:                 "cockroachdb",
+			createFormatters:     formatters.CockroachdbCreateFormatters,
+			valFormatters:        formatters.CockroachdbValFormatters,
+			convertedValues:      formatters.CockroachdbConvertedValues,
+			quoteStart:           `"`,
+			quoteEnd:             `"`,
+			dropTableStarter:     "drop table if exists",
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "length",
+			batchCheckNum:        8000000,
+		},
+		sqlDialect{
+			name:                 "redshift",
+			createFormatters:     formatters.RedshiftCreateFormatters,
+			valFormatters:        formatters.RedshiftValFormatters,
+			convertedValues:      formatters.RedshiftConvertedValues,
+			quoteStart:           `"`,
+			quoteEnd:             `"`,
+			dropTableStarter:     "drop table if exists",
+			noChecks:             true,
+			truncateTableStarter: "truncate table",
+			batchCheckType:       "length",
+			batchCheckNum:        8000000,
+		},
+	} {
+		Register(dialect)
+	}
+}
+
+type mssqlDialect struct {
+	sqlDialect
+	objectIdDrop bool
+}
+
+// ForServerVersion guards drops with object_id before SQL Server 2016, which
+// is version 13 and the first with drop table if exists.
+func (d mssqlDialect) ForServerVersion(serverVersion ServerVersion) Dialect {
+	major := serverVersion.Major()
+	d.objectIdDrop = major > 0 && major < 13
+	return d
+}
+
+func (d mssqlDialect) DropTableCommand(table string) string {
+	if d.objectIdDrop {
+		return fmt.Sprintf("if object_id('%v', 'U') is not null drop table %v", strings.ReplaceAll(table, "'", "''"), table)
+	}
+	return d.sqlDialect.DropTableCommand(table)
+}
+
+type oracleDialect struct {
+	sqlDialect
+}
+
+// ForServerVersion uses drop table if exists from Oracle 23, the first
+// version with it.
+func (d oracleDialect) ForServerVersion(serverVersion ServerVersion) Dialect {
+	if serverVersion.Major() >= 23 {
+		d.dropTableStarter = "drop table if exists"
+	}
+	return d
+}
+
+// InsertSyntax uses insert all, since oracle has no multi row values clause,
+// so each row gets its own into.
+func (d oracleDialect) InsertSyntax(table, columns string) (batchStarter, rowStarter, batchEnder string) {
+	rowStarter = fmt.Sprintf(" into %v (%v) values (", table, columns)
+	return "insert all" + rowStarter, rowStarter, " select 1 from dual"
+}
+
+type clickhouseDialect struct {
+	sqlDialect
+}
+
+func (d clickhouseDialect) CreateTableCommand(table string, columns []string, options TableOptions) string {
+	return d.sqlDialect.CreateTableCommand(table, columns, options) + shared.ClickhouseTableEngine(options.Engine, options.OrderBy)
+}
@@ -0,0 +1,143 @@
+package dialects
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+// TypeMappings maps target system types to source column types, given as ODBC
+// types such as SQL_DOUBLE or native types such as jsonb, to DDL templates
+// such as varchar({{length}}).
+type TypeMappings map[string]map[string]string
+
+var typeTemplatePlaceholder = regexp.MustCompile(`{{\s*(\w+)\s*}}`)
+
+var typeTemplateValues = map[string]bool{
+	"length":    true,
+	"precision": true,
+	"scale":     true,
+}
+
+// LoadTypeMappings reads and validates a JSON type mapping file.
+func LoadTypeMappings(path string) (TypeMappings, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading type mappings file: %v", err)
+	}
+	typeMappings := TypeMappings{}
+	err = json.Unmarshal(contents, &typeMappings)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing type mappings file: %v", err)
+	}
+	problems := []string{}
+	for systemType, mappings := range typeMappings {
+		if _, ok := Get(systemType); !ok {
+			problems = append(problems, fmt.Sprintf("unknown system type %v", systemType))
+			continue
+		}
+		for _, problem := range ValidateTypeMappings(mappings) {
+			problems = append(problems, fmt.Sprintf("%v: %v", systemType, problem))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("invalid type mappings file: %v", strings.Join(problems, "; "))
+	}
+	return typeMappings, nil
+}
+
+// ValidateTypeMappings returns a problem for each mapping from a column type
+// no dialect knows, or to a template with an unknown placeholder.
+func ValidateTypeMappings(mappings map[string]string) []string {
+	knownTypes := map[string]bool{}
+	for _, dialect := range registry {
+		for _, sourceType := range dialect.SourceTypes() {
+			knownTypes[sourceType] = true
+		}
+	}
+	problems := []string{}
+	for sourceType, template := range mappings {
+		if !knownTypes[sourceType] {
+			problems = append(problems, fmt.Sprintf("unknown column type %v", sourceType))
+		}
+		if strings.TrimSpace(template) == "" {
+			problems = append(problems, fmt.Sprintf("empty template for %v", sourceType))
+		}
+		for _, match := range typeTemplatePlaceholder.FindAllStringSubmatch(template, -1) {
+			if !typeTemplateValues[match[1]] {
+				problems = append(problems, fmt.Sprintf("unknown placeholder %v in template for %v", match[0], sourceType))
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// MergeTypeMappings returns the mappings with the overrides applied on top.
+func MergeTypeMappings(mappings, overrides map[string]string) map[string]string {
+	merged := map[string]string{}
+	for sourceType, template := range mappings {
+		merged[sourceType] = template
+	}
+	for sourceType, template := range overrides {
+		merged[sourceType] = template
+	}
+	return merged
+}
+
+// WithTypeMappings returns the dialect with its create formatters replaced by
+// templates for the mapped column types.
+func WithTypeMappings(dialect Dialect, mappings map[string]string) Dialect {
+	if len(mappings) == 0 {
+		return dialect
+	}
+	return mappedDialect{Dialect: dialect, mappings: mappings}
+}
+
+type mappedDialect struct {
+	Dialect
+	mappings map[string]string
+}
+
+func (d mappedDialect) CreateFormatter(colDbType string) (func(column *sql.ColumnType, terminator string) (string, error), bool) {
+	template, ok := shared.Lookup(d.mappings, colDbType)
+	if !ok {
+		return d.Dialect.CreateFormatter(colDbType)
+	}
+	return func(column *sql.ColumnType, terminator string) (string, error) {
+		typeName, err := RenderTypeTemplate(template, column)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v %v%v", column.Name(), typeName, terminator), nil
+	}, true
+}
+
+// RenderTypeTemplate fills a DDL template's placeholders from the column.
+func RenderTypeTemplate(template string, column *sql.ColumnType) (string, error) {
+	var err error
+	rendered := typeTemplatePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		var value int64
+		var ok bool
+		switch typeTemplatePlaceholder.FindStringSubmatch(placeholder)[1] {
+		case "length":
+			value, ok = column.Length()
+		case "precision":
+			value, _, ok = column.DecimalSize()
+		case "scale":
+			_, value, ok = column.DecimalSize()
+		}
+		if !ok && err == nil {
+			err = fmt.Errorf("column %v has no value for %v in type template %v", column.Name(), placeholder, template)
+		}
+		return fmt.Sprint(value)
+	})
+	return rendered, err
+}
@@ -0,0 +1,101 @@
+package dialects
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sqlpipe/odbc"
+	"github.com/sqlpipe/odbc/api"
+)
+
+// ServerVersion is the database product and version a connection reports.
+type ServerVersion struct {
+	Product string `json:"product"`
+	Version string `json:"version"`
+}
+
+// Major returns the leading number of the version, or 0 if there isn't one.
+func (v ServerVersion) Major() int {
+	digits := strings.TrimSpace(v.Version)
+	end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' })
+	if end >= 0 {
+		digits = digits[:end]
+	}
+	major, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0
+	}
+	return major
+}
+
+// serverVersions caches DetectServerVersion's results by *sql.DB.
+var serverVersions sync.Map
+
+// DetectServerVersion asks the ODBC driver for the product name and version
+// of the database behind db. The result is cached until ForgetServerVersion
+// is called with db.
+func DetectServerVersion(ctx context.Context, db *sql.DB) (ServerVersion, error) {
+	if serverVersion, ok := serverVersions.Load(db); ok {
+		return serverVersion.(ServerVersion), nil
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return ServerVersion{}, fmt.Errorf("error getting connection: %w", err)
+	}
+	defer conn.Close()
+
+	var serverVersion ServerVersion
+	err = conn.Raw(func(driverConn any) error {
+		odbcConn, ok := driverConn.(*odbc.Conn)
+		if !ok {
+			return errors.New("connection is not an odbc connection")
+		}
+		serverVersion.Product, err = odbcConn.GetInfoString(api.SQL_DBMS_NAME)
+		if err != nil {
+			return err
+		}
+		serverVersion.Version, err = odbcConn.GetInfoString(api.SQL_DBMS_VER)
+		return err
+	})
+	if err != nil {
+		return ServerVersion{}, fmt.Errorf("error detecting server version: %w", err)
+	}
+	serverVersions.Store(db, serverVersion)
+	return serverVersion, nil
+}
+
+// ForgetServerVersion drops db's cached version once db is closed.
+func ForgetServerVersion(db *sql.DB) {
+	serverVersions.Delete(db)
+}
+
+// versionedDialect is implemented by dialects whose generated SQL depends on
+// the server version.
+type versionedDialect interface {
+	ForServerVersion(serverVersion ServerVersion) Dialect
+}
+
+// ForServerVersion adapts the dialect to the server it will write to.
+func ForServerVersion(dialect Dialect, serverVersion ServerVersion) Dialect {
+	versioned, ok := dialect.(versionedDialect)
+	if !ok {
+		return dialect
+	}
+	return versioned.ForServerVersion(serverVersion)
+}
+
+// ForTarget specializes dialect for the server behind db. If the version
+// can't be detected, the unversioned dialect is returned as is.
+func ForTarget(ctx context.Context, dialect Dialect, db *sql.DB) Dialect {
+	serverVersion, err := DetectServerVersion(ctx, db)
+	if err != nil {
+		return dialect
+	}
+	return ForServerVersion(dialect, serverVersion)
+}
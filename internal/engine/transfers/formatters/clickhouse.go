@@ -0,0 +1,170 @@
+package formatters
+
+import (
+	"database/sql"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+var ClickhouseCreateFormatters = map[string]func(column *sql.ColumnType, terminator string) (string, error){
+	"SQL_UNKNOWN_TYPE":    shared.ClickhouseStringCreateFormatter,
+	"SQL_CHAR":            shared.ClickhouseStringCreateFormatter,
+	"SQL_NUMERIC":         shared.ClickhouseDecimalCreateFormatter,
+	"SQL_DECIMAL":         shared.ClickhouseDecimalCreateFormatter,
+	"SQL_INTEGER":         shared.ClickhouseInt32CreateFormatter,
+	"SQL_SMALLINT":        shared.ClickhouseInt16CreateFormatter,
+	"SQL_FLOAT":           shared.ClickhouseFloat64CreateFormatter,
+	"SQL_REAL":            shared.ClickhouseFloat32CreateFormatter,
+	"SQL_DOUBLE":          shared.ClickhouseFloat64CreateFormatter,
+	"SQL_DATETIME":        shared.ClickhouseDateTimeCreateFormatter,
+	"SQL_TIME":            shared.ClickhouseStringCreateFormatter,
+	"SQL_VARCHAR":         shared.ClickhouseStringCreateFormatter,
+	"SQL_TYPE_DATE":       shared.ClickhouseDateCreateFormatter,
+	"SQL_TYPE_TIME":       shared.ClickhouseStringCreateFormatter,
+	"SQL_TYPE_TIMESTAMP":  shared.ClickhouseDateTimeCreateFormatter,
+	"SQL_TIMESTAMP":       shared.ClickhouseDateTimeCreateFormatter,
+	"SQL_LONGVARCHAR":     shared.ClickhouseStringCreateFormatter,
+	"SQL_BINARY":          shared.ClickhouseStringCreateFormatter,
+	"SQL_VARBINARY":       shared.ClickhouseStringCreateFormatter,
+	"SQL_LONGVARBINARY":   shared.ClickhouseStringCreateFormatter,
+	"SQL_BIGINT":          shared.ClickhouseInt64CreateFormatter,
+	"SQL_TINYINT":         shared.ClickhouseInt16CreateFormatter,
+	"SQL_BIT":             shared.ClickhouseBoolCreateFormatter,
+	"SQL_WCHAR":           shared.ClickhouseStringCreateFormatter,
+	"SQL_WVARCHAR":        shared.ClickhouseStringCreateFormatter,
+	"SQL_WLONGVARCHAR":    shared.ClickhouseStringCreateFormatter,
+	"SQL_GUID":            shared.ClickhouseUuidCreateFormatter,
+	"SQL_SIGNED_OFFSET":   shared.ClickhouseStringCreateFormatter,
+	"SQL_UNSIGNED_OFFSET": shared.ClickhouseStringCreateFormatter,
+	"SQL_SS_XML":          shared.ClickhouseStringCreateFormatter,
+	"SQL_SS_TIME2":        shared.ClickhouseStringCreateFormatter,
+	"SQL_SS_VARIANT":      shared.ClickhouseStringCreateFormatter,
+	"SQL_SS_UDT":          shared.ClickhouseStringCreateFormatter,
+	"uniqueidentifier":    shared.ClickhouseUuidCreateFormatter,
+	"uuid":                shared.ClickhouseUuidCreateFormatter,
+	"xml":                 shared.ClickhouseStringCreateFormatter,
+	"json":                shared.ClickhouseStringCreateFormatter,
+	"jsonb":               shared.ClickhouseStringCreateFormatter,
+	"array":               shared.ClickhouseStringCreateFormatter,
+	"interval":            shared.ClickhouseStringCreateFormatter,
+	"money":               shared.ClickhouseMoneyDecimalCreateFormatter,
+	"bit":                 shared.ClickhouseStringCreateFormatter,
+	"varbit":              shared.ClickhouseStringCreateFormatter,
+	"bit varying":         shared.ClickhouseStringCreateFormatter,
+	"mysql_geometry":      shared.ClickhouseStringCreateFormatter,
+	"tinyint unsigned":    shared.ClickhouseUInt8CreateFormatter,
+	"smallint unsigned":   shared.ClickhouseUInt16CreateFormatter,
+	"mediumint unsigned":  shared.ClickhouseUInt32CreateFormatter,
+	"int unsigned":        shared.ClickhouseUInt32CreateFormatter,
+	"integer unsigned":    shared.ClickhouseUInt32CreateFormatter,
+	"bigint unsigned":     shared.ClickhouseUInt64CreateFormatter,
+	"enum":                shared.ClickhouseLowCardinalityStringCreateFormatter,
+	"set":                 shared.ClickhouseStringCreateFormatter,
+	"timestamptz":         shared.ClickhouseDateTimeUtcCreateFormatter,
+	"timestamp_tz":        shared.ClickhouseDateTimeUtcCreateFormatter,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.ClickhouseDateTimeUtcCreateFormatter,
+	"datetimeoffset":         shared.ClickhouseDateTimeUtcCreateFormatter,
+	"smallmoney":             shared.ClickhouseMoneyDecimalCreateFormatter,
+	"sql_variant":            shared.ClickhouseStringCreateFormatter,
+	"hierarchyid":            shared.ClickhouseStringCreateFormatter,
+	"rowversion":             shared.ClickhouseStringCreateFormatter,
+
+	// Oracle
+	"timestamp with time zone":       shared.ClickhouseDateTimeUtcCreateFormatter,
+	"timestamp with local time zone": shared.ClickhouseDateTimeUtcCreateFormatter,
+	"binary_float":                   shared.ClickhouseFloat32CreateFormatter,
+	"binary_double":                  shared.ClickhouseFloat64CreateFormatter,
+}
+
+var ClickhouseValFormatters = map[string]func(value interface{}, terminator string) (formattedValue string, err error){
+	"SQL_UNKNOWN_TYPE":    shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_CHAR":            shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_NUMERIC":         shared.RawXnull,
+	"SQL_DECIMAL":         shared.RawXnull,
+	"SQL_INTEGER":         shared.RawXnull,
+	"SQL_SMALLINT":        shared.RawXnull,
+	"SQL_FLOAT":           shared.RawXnull,
+	"SQL_REAL":            shared.RawXnull,
+	"SQL_DOUBLE":          shared.RawXnull,
+	"SQL_DATETIME":        shared.ClickhouseDateTimeXnull,
+	"SQL_TIME":            shared.CastToTimeFormatToTimeStringXnull,
+	"SQL_VARCHAR":         shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_TYPE_DATE":       shared.CastToTimeFormatToIsoDateStringXnull,
+	"SQL_TYPE_TIME":       shared.CastToTimeFormatToTimeStringXnull,
+	"SQL_TYPE_TIMESTAMP":  shared.ClickhouseDateTimeXnull,
+	"SQL_TIMESTAMP":       shared.ClickhouseDateTimeXnull,
+	"SQL_LONGVARCHAR":     shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_BINARY":          shared.ClickhouseBinaryXnull,
+	"SQL_VARBINARY":       shared.ClickhouseBinaryXnull,
+	"SQL_LONGVARBINARY":   shared.ClickhouseBinaryXnull,
+	"SQL_BIGINT":          shared.RawXnull,
+	"SQL_TINYINT":         shared.RawXnull,
+	"SQL_BIT":             shared.CastToBoolWriteTextEquivalentXnull,
+	"SQL_WCHAR":           shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_WVARCHAR":        shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_WLONGVARCHAR":    shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_GUID":            shared.QuotedXnull,
+	"SQL_SIGNED_OFFSET":   shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_UNSIGNED_OFFSET": shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_SS_XML":          shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_SS_TIME2":        shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_SS_VARIANT":      shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_SS_UDT":          shared.ClickhouseBinaryXnull,
+	"xml":                 shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"json":                shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"jsonb":               shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"array":               shared.PgTextArrayToEscapedJsonXnull,
+	"int2[]":              shared.PgNumericArrayToEscapedJsonXnull,
+	"int4[]":              shared.PgNumericArrayToEscapedJsonXnull,
+	"int8[]":              shared.PgNumericArrayToEscapedJsonXnull,
+	"float4[]":            shared.PgNumericArrayToEscapedJsonXnull,
+	"float8[]":            shared.PgNumericArrayToEscapedJsonXnull,
+	"numeric[]":           shared.PgNumericArrayToEscapedJsonXnull,
+	"oid[]":               shared.PgNumericArrayToEscapedJsonXnull,
+	"bool[]":              shared.PgBoolArrayToEscapedJsonXnull,
+	"interval":            shared.PgIntervalToIso8601Xnull,
+	"money":               shared.PgMoneyToNumericXnull,
+	"bit":                 shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"varbit":              shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"bit varying":         shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"mysql_geometry":      shared.MysqlGeometryToWktXnull,
+	"timestamptz":         shared.ClickhouseDateTimeUtcXnull,
+	"timestamp_tz":        shared.ClickhouseDateTimeUtcXnull,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.ClickhouseDateTimeTextXnull,
+	"datetimeoffset":         shared.ClickhouseDateTimeTextXnull,
+	"smallmoney":             shared.PgMoneyToNumericXnull,
+	"sql_variant":            shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"hierarchyid":            shared.ClickhouseBinaryXnull,
+	"rowversion":             shared.ClickhouseBinaryXnull,
+
+	// Oracle
+	"timestamp with time zone":       shared.ClickhouseDateTimeUtcXnull,
+	"timestamp with local time zone": shared.ClickhouseDateTimeUtcXnull,
+}
+
+var ClickhouseConvertedValues = map[string]bool{
+	"SQL_BIT":                        true,
+	"SQL_SS_TIMESTAMPOFFSET":         true,
+	"array":                          true,
+	"bool[]":                         true,
+	"datetimeoffset":                 true,
+	"float4[]":                       true,
+	"float8[]":                       true,
+	"int2[]":                         true,
+	"int4[]":                         true,
+	"int8[]":                         true,
+	"interval":                       true,
+	"money":                          true,
+	"mysql_geometry":                 true,
+	"numeric[]":                      true,
+	"oid[]":                          true,
+	"smallmoney":                     true,
+	"timestamp with local time zone": true,
+	"timestamp with time zone":       true,
+	"timestamp_tz":                   true,
+	"timestamptz":                    true,
+}
@@ -0,0 +1,19 @@
+package formatters
+
+import (
+	"database/sql"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+// CockroachDB speaks PostgreSQL, but has no xml, money or cidr types.
+var CockroachdbCreateFormatters = inheritCreateFormatters(PostgresqlCreateFormatters, map[string]func(column *sql.ColumnType, terminator string) (string, error){
+	"SQL_SS_XML": shared.TextCreateFormatter,
+	"xml":        shared.TextCreateFormatter,
+	"money":      shared.MoneyNumericCreateFormatter,
+	"cidr":       shared.TextCreateFormatter,
+})
+
+var CockroachdbValFormatters = inheritValFormatters(PostgresqlValFormatters, map[string]func(value interface{}, terminator string) (formattedValue string, err error){})
+
+var CockroachdbConvertedValues = inheritConvertedValues(PostgresqlConvertedValues, map[string]bool{})
@@ -0,0 +1,164 @@
+package formatters
+
+import (
+	"database/sql"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+var DuckdbCreateFormatters = map[string]func(column *sql.ColumnType, terminator string) (string, error){
+	"SQL_UNKNOWN_TYPE":    shared.TextCreateFormatter,
+	"SQL_CHAR":            shared.TextCreateFormatter,
+	"SQL_NUMERIC":         shared.DuckdbDecimalCreateFormatter,
+	"SQL_DECIMAL":         shared.DuckdbDecimalCreateFormatter,
+	"SQL_INTEGER":         shared.IntCreateFormatter,
+	"SQL_SMALLINT":        shared.SmallIntCreateFormatter,
+	"SQL_FLOAT":           shared.DoubleCreateFormatter,
+	"SQL_REAL":            shared.RealCreateFormatter,
+	"SQL_DOUBLE":          shared.DoubleCreateFormatter,
+	"SQL_DATETIME":        shared.TimestampCreateFormatter,
+	"SQL_TIME":            shared.TimeCreateFormatter,
+	"SQL_VARCHAR":         shared.TextCreateFormatter,
+	"SQL_TYPE_DATE":       shared.DateCreateFormatter,
+	"SQL_TYPE_TIME":       shared.TimeCreateFormatter,
+	"SQL_TYPE_TIMESTAMP":  shared.TimestampCreateFormatter,
+	"SQL_TIMESTAMP":       shared.TimestampCreateFormatter,
+	"SQL_LONGVARCHAR":     shared.TextCreateFormatter,
+	"SQL_BINARY":          shared.BlobCreateFormatter,
+	"SQL_VARBINARY":       shared.BlobCreateFormatter,
+	"SQL_LONGVARBINARY":   shared.BlobCreateFormatter,
+	"SQL_BIGINT":          shared.BigIntCreateFormatter,
+	"SQL_TINYINT":         shared.SmallIntCreateFormatter,
+	"SQL_BIT":             shared.BooleanCreateFormatter,
+	"SQL_WCHAR":           shared.TextCreateFormatter,
+	"SQL_WVARCHAR":        shared.TextCreateFormatter,
+	"SQL_WLONGVARCHAR":    shared.TextCreateFormatter,
+	"SQL_GUID":            shared.UuidCreateFormatter,
+	"SQL_SIGNED_OFFSET":   shared.TextCreateFormatter,
+	"SQL_UNSIGNED_OFFSET": shared.TextCreateFormatter,
+	"SQL_SS_XML":          shared.TextCreateFormatter,
+	"SQL_SS_TIME2":        shared.TimeCreateFormatter,
+	"SQL_SS_VARIANT":      shared.TextCreateFormatter,
+	"SQL_SS_UDT":          shared.BlobCreateFormatter,
+	"uniqueidentifier":    shared.UuidCreateFormatter,
+	"uuid":                shared.UuidCreateFormatter,
+	"xml":                 shared.TextCreateFormatter,
+	"json":                shared.JsonCreateFormatter,
+	"jsonb":               shared.JsonCreateFormatter,
+	"array":               shared.JsonCreateFormatter,
+	"interval":            shared.TextCreateFormatter,
+	"money":               shared.MoneyNumericCreateFormatter,
+	"bit":                 shared.TextCreateFormatter,
+	"varbit":              shared.TextCreateFormatter,
+	"bit varying":         shared.TextCreateFormatter,
+	"mysql_geometry":      shared.TextCreateFormatter,
+	"tinyint unsigned":    shared.SmallIntCreateFormatter,
+	"smallint unsigned":   shared.IntCreateFormatter,
+	"mediumint unsigned":  shared.IntCreateFormatter,
+	"int unsigned":        shared.BigIntCreateFormatter,
+	"integer unsigned":    shared.BigIntCreateFormatter,
+	"bigint unsigned":     shared.UbigintCreateFormatter,
+	"enum":                shared.TextCreateFormatter,
+	"set":                 shared.TextCreateFormatter,
+	"timestamptz":         shared.TimestamptzCreateFormatter,
+	"timestamp_tz":        shared.TimestamptzCreateFormatter,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.TimestamptzCreateFormatter,
+	"datetimeoffset":         shared.TimestamptzCreateFormatter,
+	"smallmoney":             shared.MoneyNumericCreateFormatter,
+	"sql_variant":            shared.TextCreateFormatter,
+	"hierarchyid":            shared.BlobCreateFormatter,
+	"rowversion":             shared.BlobCreateFormatter,
+
+	// Oracle
+	"timestamp with time zone":       shared.TimestamptzCreateFormatter,
+	"timestamp with local time zone": shared.TimestamptzCreateFormatter,
+	"binary_float":                   shared.RealCreateFormatter,
+	"binary_double":                  shared.DoubleCreateFormatter,
+}
+
+var DuckdbValFormatters = map[string]func(value interface{}, terminator string) (formattedValue string, err error){
+	"SQL_UNKNOWN_TYPE":    shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_CHAR":            shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_NUMERIC":         shared.RawXnull,
+	"SQL_DECIMAL":         shared.RawXnull,
+	"SQL_INTEGER":         shared.RawXnull,
+	"SQL_SMALLINT":        shared.RawXnull,
+	"SQL_FLOAT":           shared.RawXnull,
+	"SQL_REAL":            shared.RawXnull,
+	"SQL_DOUBLE":          shared.RawXnull,
+	"SQL_DATETIME":        shared.CastToTimeFormatToIsoTimestampStringXnull,
+	"SQL_TIME":            shared.CastToTimeFormatToTimeStringXnull,
+	"SQL_VARCHAR":         shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_TYPE_DATE":       shared.CastToTimeFormatToIsoDateStringXnull,
+	"SQL_TYPE_TIME":       shared.CastToTimeFormatToTimeStringXnull,
+	"SQL_TYPE_TIMESTAMP":  shared.CastToTimeFormatToIsoTimestampStringXnull,
+	"SQL_TIMESTAMP":       shared.CastToTimeFormatToIsoTimestampStringXnull,
+	"SQL_LONGVARCHAR":     shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_BINARY":          shared.DuckdbBlobXnull,
+	"SQL_VARBINARY":       shared.DuckdbBlobXnull,
+	"SQL_LONGVARBINARY":   shared.DuckdbBlobXnull,
+	"SQL_BIGINT":          shared.RawXnull,
+	"SQL_TINYINT":         shared.RawXnull,
+	"SQL_BIT":             shared.CastToBoolWriteTextEquivalentXnull,
+	"SQL_WCHAR":           shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_WVARCHAR":        shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_WLONGVARCHAR":    shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_GUID":            shared.QuotedXnull,
+	"SQL_SIGNED_OFFSET":   shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_UNSIGNED_OFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_XML":          shared.CastToStringPrintQuotedLiteralXnull,
+	"SQL_SS_TIME2":        shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_VARIANT":      shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_UDT":          shared.DuckdbBlobXnull,
+	"xml":                 shared.CastToStringPrintQuotedLiteralXnull,
+	"json":                shared.CastToStringPrintQuotedLiteralXnull,
+	"jsonb":               shared.CastToStringPrintQuotedLiteralXnull,
+	"array":               shared.PgTextArrayToJsonXnull,
+	"int2[]":              shared.PgNumericArrayToJsonXnull,
+	"int4[]":              shared.PgNumericArrayToJsonXnull,
+	"int8[]":              shared.PgNumericArrayToJsonXnull,
+	"float4[]":            shared.PgNumericArrayToJsonXnull,
+	"float8[]":            shared.PgNumericArrayToJsonXnull,
+	"numeric[]":           shared.PgNumericArrayToJsonXnull,
+	"oid[]":               shared.PgNumericArrayToJsonXnull,
+	"bool[]":              shared.PgBoolArrayToJsonXnull,
+	"interval":            shared.PgIntervalToIso8601Xnull,
+	"money":               shared.PgMoneyToNumericXnull,
+	"bit":                 shared.CastToStringPrintQuotedLiteralXnull,
+	"varbit":              shared.CastToStringPrintQuotedLiteralXnull,
+	"bit varying":         shared.CastToStringPrintQuotedLiteralXnull,
+	"mysql_geometry":      shared.MysqlGeometryToWktXnull,
+	"timestamptz":         shared.CastToTimeFormatToMysqlTimetampStringXnull,
+	"timestamp_tz":        shared.CastToTimeFormatToMysqlTimetampStringXnull,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
+	"datetimeoffset":         shared.CastToBytesCastToStringPrintQuotedXnull,
+	"smallmoney":             shared.PgMoneyToNumericXnull,
+	"sql_variant":            shared.CastToBytesCastToStringPrintQuotedXnull,
+	"hierarchyid":            shared.DuckdbBlobXnull,
+	"rowversion":             shared.DuckdbBlobXnull,
+
+	// Oracle
+	"timestamp with time zone":       shared.CastToTimeFormatToMysqlTimetampStringXnull,
+	"timestamp with local time zone": shared.CastToTimeFormatToMysqlTimetampStringXnull,
+}
+
+var DuckdbConvertedValues = map[string]bool{
+	"SQL_BIT":        true,
+	"array":          true,
+	"bool[]":         true,
+	"float4[]":       true,
+	"float8[]":       true,
+	"int2[]":         true,
+	"int4[]":         true,
+	"int8[]":         true,
+	"interval":       true,
+	"money":          true,
+	"mysql_geometry": true,
+	"numeric[]":      true,
+	"oid[]":          true,
+	"smallmoney":     true,
+}
@@ -0,0 +1,50 @@
+package formatters
+
+import (
+	"database/sql"
+)
+
+// inheritCreateFormatters copies a dialect's create formatters for a variant
+// of it, replacing only the types the variant handles differently.
+func inheritCreateFormatters(
+	base map[string]func(column *sql.ColumnType, terminator string) (string, error),
+	overrides map[string]func(column *sql.ColumnType, terminator string) (string, error),
+) map[string]func(column *sql.ColumnType, terminator string) (string, error) {
+	inherited := make(map[string]func(column *sql.ColumnType, terminator string) (string, error), len(base)+len(overrides))
+	for key, formatter := range base {
+		inherited[key] = formatter
+	}
+	for key, formatter := range overrides {
+		inherited[key] = formatter
+	}
+	return inherited
+}
+
+// inheritValFormatters is inheritCreateFormatters for value formatters.
+func inheritValFormatters(
+	base map[string]func(value interface{}, terminator string) (formattedValue string, err error),
+	overrides map[string]func(value interface{}, terminator string) (formattedValue string, err error),
+) map[string]func(value interface{}, terminator string) (formattedValue string, err error) {
+	inherited := make(map[string]func(value interface{}, terminator string) (formattedValue string, err error), len(base)+len(overrides))
+	for key, formatter := range base {
+		inherited[key] = formatter
+	}
+	for key, formatter := range overrides {
+		inherited[key] = formatter
+	}
+	return inherited
+}
+
+// inheritConvertedValues is inheritCreateFormatters for the types whose values
+// a dialect converts. Overrides set to false mark types the variant writes
+// as they are read.
+func inheritConvertedValues(base map[string]bool, overrides map[string]bool) map[string]bool {
+	inherited := make(map[string]bool, len(base)+len(overrides))
+	for key, converted := range base {
+		inherited[key] = converted
+	}
+	for key, converted := range overrides {
+		inherited[key] = converted
+	}
+	return inherited
+}
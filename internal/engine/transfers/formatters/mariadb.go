@@ -0,0 +1,13 @@
+package formatters
+
+import (
+	"database/sql"
+)
+
+// MariaDB accepts the same DDL and literals as MySQL for every type sqlpipe
+// writes, so it only differs in its batching limits.
+var MariadbCreateFormatters = inheritCreateFormatters(MysqlCreateFormatters, map[string]func(column *sql.ColumnType, terminator string) (string, error){})
+
+var MariadbValFormatters = inheritValFormatters(MysqlValFormatters, map[string]func(value interface{}, terminator string) (formattedValue string, err error){})
+
+var MariadbConvertedValues = inheritConvertedValues(MysqlConvertedValues, map[string]bool{})
@@ -38,6 +38,42 @@ var MssqlCreateFormatters = map[string]func(column *sql.ColumnType, terminator s
 	"SQL_UNSIGNED_OFFSET": shared.NTextCreateFormatter,
 	"SQL_SS_XML":          shared.XmlCreateFormatter,
 	"SQL_SS_TIME2":        shared.TimeCreateFormatter,
+	"SQL_SS_VARIANT":      shared.SqlVariantCreateFormatter,
+	"SQL_SS_UDT":          shared.VarbinaryMaxCreateFormatter,
+	"uniqueidentifier":    shared.UniqueIdentifierCreateFormatter,
+	"uuid":                shared.UniqueIdentifierCreateFormatter,
+	"xml":                 shared.XmlCreateFormatter,
+	"json":                shared.NVarcharMaxCreateFormatter,
+	"jsonb":               shared.NVarcharMaxCreateFormatter,
+	"array":               shared.NVarcharMaxCreateFormatter,
+	"interval":            shared.NVarcharMaxCreateFormatter,
+	"money":               shared.MoneyNumericCreateFormatter,
+	"bit":                 shared.VarcharMaxCreateFormatter,
+	"varbit":              shared.VarcharMaxCreateFormatter,
+	"bit varying":         shared.VarcharMaxCreateFormatter,
+	"mysql_geometry":      shared.NVarcharMaxCreateFormatter,
+	"tinyint unsigned":    shared.SmallIntCreateFormatter,
+	"smallint unsigned":   shared.IntCreateFormatter,
+	"mediumint unsigned":  shared.IntCreateFormatter,
+	"int unsigned":        shared.BigIntCreateFormatter,
+	"integer unsigned":    shared.BigIntCreateFormatter,
+	"bigint unsigned":     shared.UnsignedBigIntNumericCreateFormatter,
+	"enum":                shared.VarcharCreateFormatter,
+	"set":                 shared.VarcharCreateFormatter,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET":   shared.DatetimeoffsetCreateFormatter,
+	"datetimeoffset":           shared.DatetimeoffsetCreateFormatter,
+	"smallmoney":               shared.MoneyNumericCreateFormatter,
+	"sql_variant":              shared.SqlVariantCreateFormatter,
+	"hierarchyid":              shared.HierarchyidCreateFormatter,
+	"rowversion":               shared.RowversionBinaryCreateFormatter,
+	"timestamptz":              shared.DatetimeoffsetCreateFormatter,
+	"timestamp with time zone": shared.DatetimeoffsetCreateFormatter,
+	"timestamp_tz":             shared.DatetimeoffsetCreateFormatter,
+
+	// Oracle
+	"timestamp with local time zone": shared.DatetimeoffsetCreateFormatter,
 }
 
 var MssqlValFormatters = map[string]func(value interface{}, terminator string) (formattedValue string, err error){
@@ -70,6 +106,62 @@ var MssqlValFormatters = map[string]func(value interface{}, terminator string) (
 	"SQL_GUID":            shared.QuotedXnull,
 	"SQL_SIGNED_OFFSET":   shared.CastToBytesCastToStringPrintQuotedXnull,
 	"SQL_UNSIGNED_OFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
-	"SQL_SS_XML":          shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_XML":          shared.CastToStringPrintQuotedLiteralXnull,
 	"SQL_SS_TIME2":        shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_VARIANT":      shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_UDT":          shared.CastToBytesPrintMssqlHexXnull,
+	"json":                shared.CastToStringPrintQuotedLiteralXnull,
+	"jsonb":               shared.CastToStringPrintQuotedLiteralXnull,
+	"array":               shared.PgTextArrayToJsonXnull,
+	"int2[]":              shared.PgNumericArrayToJsonXnull,
+	"int4[]":              shared.PgNumericArrayToJsonXnull,
+	"int8[]":              shared.PgNumericArrayToJsonXnull,
+	"float4[]":            shared.PgNumericArrayToJsonXnull,
+	"float8[]":            shared.PgNumericArrayToJsonXnull,
+	"numeric[]":           shared.PgNumericArrayToJsonXnull,
+	"oid[]":               shared.PgNumericArrayToJsonXnull,
+	"bool[]":              shared.PgBoolArrayToJsonXnull,
+	"interval":            shared.PgIntervalToIso8601Xnull,
+	"money":               shared.PgMoneyToNumericXnull,
+	"bit":                 shared.PgBitStringPrintTextXnull,
+	"varbit":              shared.PgBitStringPrintTextXnull,
+	"bit varying":         shared.PgBitStringPrintTextXnull,
+	"mysql_geometry":      shared.MysqlGeometryToWktXnull,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET":   shared.CastToBytesCastToStringPrintQuotedXnull,
+	"xml":                      shared.CastToStringPrintQuotedLiteralXnull,
+	"smallmoney":               shared.PgMoneyToNumericXnull,
+	"hierarchyid":              shared.CastToBytesPrintMssqlHexXnull,
+	"rowversion":               shared.CastToBytesPrintMssqlHexXnull,
+	"timestamptz":              shared.CastToTimeFormatToMssqlDatetimeoffsetStringXnull,
+	"timestamp with time zone": shared.CastToTimeFormatToMssqlDatetimeoffsetStringXnull,
+	"timestamp_tz":             shared.CastToTimeFormatToMssqlDatetimeoffsetStringXnull,
+
+	// Oracle
+	"timestamp with local time zone": shared.CastToTimeFormatToMssqlDatetimeoffsetStringXnull,
+}
+
+var MssqlConvertedValues = map[string]bool{
+	"SQL_BIT":                        true,
+	"array":                          true,
+	"bit":                            true,
+	"bit varying":                    true,
+	"bool[]":                         true,
+	"float4[]":                       true,
+	"float8[]":                       true,
+	"int2[]":                         true,
+	"int4[]":                         true,
+	"int8[]":                         true,
+	"interval":                       true,
+	"money":                          true,
+	"mysql_geometry":                 true,
+	"numeric[]":                      true,
+	"oid[]":                          true,
+	"smallmoney":                     true,
+	"timestamp with local time zone": true,
+	"timestamp with time zone":       true,
+	"timestamp_tz":                   true,
+	"timestamptz":                    true,
+	"varbit":                         true,
 }
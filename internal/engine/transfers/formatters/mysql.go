@@ -38,6 +38,34 @@ var MysqlCreateFormatters = map[string]func(column *sql.ColumnType, terminator s
 	"SQL_UNSIGNED_OFFSET": shared.TextCreateFormatter,
 	"SQL_SS_XML":          shared.TextCreateFormatter,
 	"SQL_SS_TIME2":        shared.TimeCreateFormatter,
+	"SQL_SS_VARIANT":      shared.TextCreateFormatter,
+	"SQL_SS_UDT":          shared.LongBlobCreateFormatter,
+	"json":                shared.JsonCreateFormatter,
+	"jsonb":               shared.JsonCreateFormatter,
+	"array":               shared.JsonCreateFormatter,
+	"interval":            shared.TextCreateFormatter,
+	"money":               shared.MoneyNumericCreateFormatter,
+	"bit":                 shared.MysqlBitStringCreateFormatter,
+	"varbit":              shared.MysqlBitStringCreateFormatter,
+	"bit varying":         shared.MysqlBitStringCreateFormatter,
+	"mysql_geometry":      shared.GeometryCreateFormatter,
+	"tinyint unsigned":    shared.NativeCreateFormatter,
+	"smallint unsigned":   shared.NativeCreateFormatter,
+	"mediumint unsigned":  shared.NativeCreateFormatter,
+	"int unsigned":        shared.NativeCreateFormatter,
+	"integer unsigned":    shared.NativeCreateFormatter,
+	"bigint unsigned":     shared.NativeCreateFormatter,
+	"enum":                shared.VarcharCreateFormatter,
+	"set":                 shared.VarcharCreateFormatter,
+	"year":                shared.NativeCreateFormatter,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.DatetimeoffsetVarcharCreateFormatter,
+	"datetimeoffset":         shared.DatetimeoffsetVarcharCreateFormatter,
+	"smallmoney":             shared.MoneyNumericCreateFormatter,
+	"sql_variant":            shared.TextCreateFormatter,
+	"hierarchyid":            shared.LongBlobCreateFormatter,
+	"rowversion":             shared.RowversionBinaryCreateFormatter,
 }
 
 var MysqlValFormatters = map[string]func(value interface{}, terminator string) (formattedValue string, err error){
@@ -70,6 +98,52 @@ var MysqlValFormatters = map[string]func(value interface{}, terminator string) (
 	"SQL_GUID":            shared.QuotedXnull,
 	"SQL_SIGNED_OFFSET":   shared.CastToBytesCastToStringPrintQuotedXnull,
 	"SQL_UNSIGNED_OFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
-	"SQL_SS_XML":          shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_XML":          shared.CastToStringPrintQuotedEscapedLiteralXnull,
 	"SQL_SS_TIME2":        shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_VARIANT":      shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_UDT":          shared.CastToBytesPrintMysqlHexXnull,
+	"json":                shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"jsonb":               shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"array":               shared.PgTextArrayToEscapedJsonXnull,
+	"int2[]":              shared.PgNumericArrayToEscapedJsonXnull,
+	"int4[]":              shared.PgNumericArrayToEscapedJsonXnull,
+	"int8[]":              shared.PgNumericArrayToEscapedJsonXnull,
+	"float4[]":            shared.PgNumericArrayToEscapedJsonXnull,
+	"float8[]":            shared.PgNumericArrayToEscapedJsonXnull,
+	"numeric[]":           shared.PgNumericArrayToEscapedJsonXnull,
+	"oid[]":               shared.PgNumericArrayToEscapedJsonXnull,
+	"bool[]":              shared.PgBoolArrayToEscapedJsonXnull,
+	"interval":            shared.PgIntervalToIso8601Xnull,
+	"money":               shared.PgMoneyToNumericXnull,
+	"bit":                 shared.PgBitStringPrintMysqlBitXnull,
+	"varbit":              shared.PgBitStringPrintMysqlBitXnull,
+	"bit varying":         shared.PgBitStringPrintMysqlBitXnull,
+	"mysql_geometry":      shared.MysqlGeometryToMysqlWkbXnull,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
+	"xml":                    shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"smallmoney":             shared.PgMoneyToNumericXnull,
+	"hierarchyid":            shared.CastToBytesPrintMysqlHexXnull,
+	"rowversion":             shared.CastToBytesPrintMysqlHexXnull,
+}
+
+var MysqlConvertedValues = map[string]bool{
+	"SQL_BIT":        true,
+	"array":          true,
+	"bit":            true,
+	"bit varying":    true,
+	"bool[]":         true,
+	"float4[]":       true,
+	"float8[]":       true,
+	"int2[]":         true,
+	"int4[]":         true,
+	"int8[]":         true,
+	"interval":       true,
+	"money":          true,
+	"mysql_geometry": true,
+	"numeric[]":      true,
+	"oid[]":          true,
+	"smallmoney":     true,
+	"varbit":         true,
 }
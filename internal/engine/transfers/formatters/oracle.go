@@ -0,0 +1,191 @@
+package formatters
+
+import (
+	"database/sql"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+var OracleCreateFormatters = map[string]func(column *sql.ColumnType, terminator string) (string, error){
+	"SQL_UNKNOWN_TYPE":    shared.OracleClobCreateFormatter,
+	"SQL_CHAR":            shared.OracleVarchar2CreateFormatter,
+	"SQL_NUMERIC":         shared.OracleNumberCreateFormatter,
+	"SQL_DECIMAL":         shared.OracleNumberCreateFormatter,
+	"SQL_INTEGER":         shared.IntCreateFormatter,
+	"SQL_SMALLINT":        shared.SmallIntCreateFormatter,
+	"SQL_FLOAT":           shared.BinaryDoubleCreateFormatter,
+	"SQL_REAL":            shared.BinaryFloatCreateFormatter,
+	"SQL_DOUBLE":          shared.BinaryDoubleCreateFormatter,
+	"SQL_DATETIME":        shared.OracleTimestampCreateFormatter,
+	"SQL_TIME":            shared.OracleTimeCreateFormatter,
+	"SQL_VARCHAR":         shared.OracleVarchar2CreateFormatter,
+	"SQL_TYPE_DATE":       shared.DateCreateFormatter,
+	"SQL_TYPE_TIME":       shared.OracleTimeCreateFormatter,
+	"SQL_TYPE_TIMESTAMP":  shared.OracleTimestampCreateFormatter,
+	"SQL_TIMESTAMP":       shared.OracleTimestampCreateFormatter,
+	"SQL_LONGVARCHAR":     shared.OracleClobCreateFormatter,
+	"SQL_BINARY":          shared.OracleRawCreateFormatter,
+	"SQL_VARBINARY":       shared.OracleRawCreateFormatter,
+	"SQL_LONGVARBINARY":   shared.OracleBlobCreateFormatter,
+	"SQL_BIGINT":          shared.IntCreateFormatter,
+	"SQL_TINYINT":         shared.SmallIntCreateFormatter,
+	"SQL_BIT":             shared.OracleBooleanCreateFormatter,
+	"SQL_WCHAR":           shared.OracleVarchar2CreateFormatter,
+	"SQL_WVARCHAR":        shared.OracleVarchar2CreateFormatter,
+	"SQL_WLONGVARCHAR":    shared.OracleClobCreateFormatter,
+	"SQL_GUID":            shared.OracleUuidCreateFormatter,
+	"SQL_SIGNED_OFFSET":   shared.OracleClobCreateFormatter,
+	"SQL_UNSIGNED_OFFSET": shared.OracleClobCreateFormatter,
+	"SQL_SS_XML":          shared.OracleClobCreateFormatter,
+	"SQL_SS_TIME2":        shared.OracleTimeCreateFormatter,
+	"SQL_SS_VARIANT":      shared.OracleClobCreateFormatter,
+	"SQL_SS_UDT":          shared.OracleBlobCreateFormatter,
+	"uniqueidentifier":    shared.OracleUuidCreateFormatter,
+	"uuid":                shared.OracleUuidCreateFormatter,
+	"xml":                 shared.OracleClobCreateFormatter,
+	"json":                shared.OracleClobCreateFormatter,
+	"jsonb":               shared.OracleClobCreateFormatter,
+	"array":               shared.OracleClobCreateFormatter,
+	"interval":            shared.OracleVarchar2CreateFormatter,
+	"money":               shared.MoneyNumericCreateFormatter,
+	"bit":                 shared.OracleBlobCreateFormatter,
+	"varbit":              shared.OracleBlobCreateFormatter,
+	"bit varying":         shared.OracleBlobCreateFormatter,
+	"mysql_geometry":      shared.OracleClobCreateFormatter,
+	"tinyint unsigned":    shared.IntCreateFormatter,
+	"smallint unsigned":   shared.IntCreateFormatter,
+	"mediumint unsigned":  shared.IntCreateFormatter,
+	"int unsigned":        shared.IntCreateFormatter,
+	"integer unsigned":    shared.IntCreateFormatter,
+	"bigint unsigned":     shared.IntCreateFormatter,
+	"enum":                shared.OracleVarchar2CreateFormatter,
+	"set":                 shared.OracleVarchar2CreateFormatter,
+	"timestamptz":         shared.OracleTimestampTzCreateFormatter,
+	"timestamp_tz":        shared.OracleTimestampTzCreateFormatter,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.OracleTimestampTzCreateFormatter,
+	"datetimeoffset":         shared.OracleTimestampTzCreateFormatter,
+	"smallmoney":             shared.MoneyNumericCreateFormatter,
+	"sql_variant":            shared.OracleClobCreateFormatter,
+	"hierarchyid":            shared.OracleBlobCreateFormatter,
+	"rowversion":             shared.OracleRowversionCreateFormatter,
+
+	// Oracle
+	"timestamp with time zone":       shared.OracleTimestampTzCreateFormatter,
+	"timestamp with local time zone": shared.OracleTimestampTzCreateFormatter,
+	"binary_float":                   shared.BinaryFloatCreateFormatter,
+	"binary_double":                  shared.BinaryDoubleCreateFormatter,
+}
+
+var OracleValFormatters = map[string]func(value interface{}, terminator string) (formattedValue string, err error){
+	"SQL_UNKNOWN_TYPE":    shared.OracleTextXnull,
+	"SQL_CHAR":            shared.OracleTextXnull,
+	"SQL_NUMERIC":         shared.RawXnull,
+	"SQL_DECIMAL":         shared.RawXnull,
+	"SQL_INTEGER":         shared.RawXnull,
+	"SQL_SMALLINT":        shared.RawXnull,
+	"SQL_FLOAT":           shared.RawXnull,
+	"SQL_REAL":            shared.RawXnull,
+	"SQL_DOUBLE":          shared.RawXnull,
+	"SQL_DATETIME":        shared.OracleTimestampXnull,
+	"SQL_TIME":            shared.CastToTimeFormatToTimeStringXnull,
+	"SQL_VARCHAR":         shared.OracleTextXnull,
+	"SQL_TYPE_DATE":       shared.OracleDateXnull,
+	"SQL_TYPE_TIME":       shared.CastToTimeFormatToTimeStringXnull,
+	"SQL_TYPE_TIMESTAMP":  shared.OracleTimestampXnull,
+	"SQL_TIMESTAMP":       shared.OracleTimestampXnull,
+	"SQL_LONGVARCHAR":     shared.OracleTextXnull,
+	"SQL_BINARY":          shared.OracleRawXnull,
+	"SQL_VARBINARY":       shared.OracleRawXnull,
+	"SQL_LONGVARBINARY":   shared.OracleRawXnull,
+	"SQL_BIGINT":          shared.RawXnull,
+	"SQL_TINYINT":         shared.RawXnull,
+	"SQL_BIT":             shared.CastToBoolWriteBinaryEquivalentXnull,
+	"SQL_WCHAR":           shared.OracleTextXnull,
+	"SQL_WVARCHAR":        shared.OracleTextXnull,
+	"SQL_WLONGVARCHAR":    shared.OracleTextXnull,
+	"SQL_GUID":            shared.QuotedXnull,
+	"SQL_SIGNED_OFFSET":   shared.OracleTextXnull,
+	"SQL_UNSIGNED_OFFSET": shared.OracleTextXnull,
+	"SQL_SS_XML":          shared.OracleLiteralXnull,
+	"SQL_SS_TIME2":        shared.OracleTextXnull,
+	"SQL_SS_VARIANT":      shared.OracleTextXnull,
+	"SQL_SS_UDT":          shared.OracleRawXnull,
+	"xml":                 shared.OracleLiteralXnull,
+	"json":                shared.OracleLiteralXnull,
+	"jsonb":               shared.OracleLiteralXnull,
+	"array":               shared.PgTextArrayToJsonXnull,
+	"int2[]":              shared.PgNumericArrayToJsonXnull,
+	"int4[]":              shared.PgNumericArrayToJsonXnull,
+	"int8[]":              shared.PgNumericArrayToJsonXnull,
+	"float4[]":            shared.PgNumericArrayToJsonXnull,
+	"float8[]":            shared.PgNumericArrayToJsonXnull,
+	"numeric[]":           shared.PgNumericArrayToJsonXnull,
+	"oid[]":               shared.PgNumericArrayToJsonXnull,
+	"bool[]":              shared.PgBoolArrayToJsonXnull,
+	"interval":            shared.PgIntervalToIso8601Xnull,
+	"money":               shared.PgMoneyToNumericXnull,
+	"bit":                 shared.PgBitStringPrintQuotedHexXnull,
+	"varbit":              shared.PgBitStringPrintQuotedHexXnull,
+	"bit varying":         shared.PgBitStringPrintQuotedHexXnull,
+	"mysql_geometry":      shared.MysqlGeometryToWktXnull,
+	"timestamptz":         shared.OracleTimestampTzXnull,
+	"timestamp_tz":        shared.OracleTimestampTzXnull,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.OracleTimestampTzTextXnull,
+	"datetimeoffset":         shared.OracleTimestampTzTextXnull,
+	"smallmoney":             shared.PgMoneyToNumericXnull,
+	"sql_variant":            shared.OracleTextXnull,
+	"hierarchyid":            shared.OracleRawXnull,
+	"rowversion":             shared.OracleRawXnull,
+
+	// Oracle
+	"timestamp with time zone":       shared.OracleTimestampTzXnull,
+	"timestamp with local time zone": shared.OracleTimestampTzXnull,
+}
+
+var OracleConvertedValues = map[string]bool{
+	"SQL_BINARY":             true,
+	"SQL_BIT":                true,
+	"SQL_CHAR":               true,
+	"SQL_LONGVARBINARY":      true,
+	"SQL_LONGVARCHAR":        true,
+	"SQL_SIGNED_OFFSET":      true,
+	"SQL_SS_TIME2":           true,
+	"SQL_SS_TIMESTAMPOFFSET": true,
+	"SQL_SS_UDT":             true,
+	"SQL_SS_VARIANT":         true,
+	"SQL_SS_XML":             true,
+	"SQL_UNKNOWN_TYPE":       true,
+	"SQL_UNSIGNED_OFFSET":    true,
+	"SQL_VARBINARY":          true,
+	"SQL_VARCHAR":            true,
+	"SQL_WCHAR":              true,
+	"SQL_WLONGVARCHAR":       true,
+	"SQL_WVARCHAR":           true,
+	"array":                  true,
+	"bit":                    true,
+	"bit varying":            true,
+	"bool[]":                 true,
+	"datetimeoffset":         true,
+	"float4[]":               true,
+	"float8[]":               true,
+	"hierarchyid":            true,
+	"int2[]":                 true,
+	"int4[]":                 true,
+	"int8[]":                 true,
+	"interval":               true,
+	"json":                   true,
+	"jsonb":                  true,
+	"money":                  true,
+	"mysql_geometry":         true,
+	"numeric[]":              true,
+	"oid[]":                  true,
+	"rowversion":             true,
+	"smallmoney":             true,
+	"sql_variant":            true,
+	"varbit":                 true,
+	"xml":                    true,
+}
@@ -38,6 +38,42 @@ var PostgresqlCreateFormatters = map[string]func(column *sql.ColumnType, termina
 	"SQL_UNSIGNED_OFFSET": shared.TextCreateFormatter,
 	"SQL_SS_XML":          shared.XmlCreateFormatter,
 	"SQL_SS_TIME2":        shared.TimeCreateFormatter,
+	"SQL_SS_VARIANT":      shared.TextCreateFormatter,
+	"SQL_SS_UDT":          shared.ByteaCreateFormatter,
+	"uniqueidentifier":    shared.UuidCreateFormatter,
+	"uuid":                shared.UuidCreateFormatter,
+	"xml":                 shared.XmlCreateFormatter,
+	"json":                shared.NativeCreateFormatter,
+	"jsonb":               shared.NativeCreateFormatter,
+	"array":               shared.NativeCreateFormatter,
+	"interval":            shared.NativeCreateFormatter,
+	"inet":                shared.NativeCreateFormatter,
+	"cidr":                shared.NativeCreateFormatter,
+	"money":               shared.PgMoneyCreateFormatter,
+	"bit":                 shared.PgBitStringCreateFormatter,
+	"varbit":              shared.PgBitStringCreateFormatter,
+	"bit varying":         shared.PgBitStringCreateFormatter,
+	"mysql_geometry":      shared.GeometryCreateFormatter,
+	"tinyint unsigned":    shared.SmallIntCreateFormatter,
+	"smallint unsigned":   shared.IntCreateFormatter,
+	"mediumint unsigned":  shared.IntCreateFormatter,
+	"int unsigned":        shared.BigIntCreateFormatter,
+	"integer unsigned":    shared.BigIntCreateFormatter,
+	"bigint unsigned":     shared.UnsignedBigIntNumericCreateFormatter,
+	"enum":                shared.VarcharCreateFormatter,
+	"set":                 shared.VarcharCreateFormatter,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.TimestamptzCreateFormatter,
+	"datetimeoffset":         shared.TimestamptzCreateFormatter,
+	"smallmoney":             shared.MoneyNumericCreateFormatter,
+	"sql_variant":            shared.TextCreateFormatter,
+	"hierarchyid":            shared.ByteaCreateFormatter,
+	"rowversion":             shared.ByteaCreateFormatter,
+
+	// Oracle
+	"timestamp with time zone":       shared.TimestamptzCreateFormatter,
+	"timestamp with local time zone": shared.TimestamptzCreateFormatter,
 }
 
 var PostgresqlValFormatters = map[string]func(value interface{}, terminator string) (formattedValue string, err error){
@@ -70,6 +106,43 @@ var PostgresqlValFormatters = map[string]func(value interface{}, terminator stri
 	"SQL_GUID":            shared.QuotedXnull,
 	"SQL_SIGNED_OFFSET":   shared.CastToBytesCastToStringPrintQuotedXnull,
 	"SQL_UNSIGNED_OFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
-	"SQL_SS_XML":          shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_XML":          shared.CastToStringPrintQuotedLiteralXnull,
 	"SQL_SS_TIME2":        shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_VARIANT":      shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_UDT":          shared.CastToBytesPrintPgByteaXnull,
+	"json":                shared.CastToStringPrintQuotedLiteralXnull,
+	"jsonb":               shared.CastToStringPrintQuotedLiteralXnull,
+	"array":               shared.CastToStringPrintQuotedLiteralXnull,
+	"money":               shared.PgMoneyToNumericXnull,
+	"bit":                 shared.PgBitStringPrintQuotedXnull,
+	"varbit":              shared.PgBitStringPrintQuotedXnull,
+	"bit varying":         shared.PgBitStringPrintQuotedXnull,
+	"mysql_geometry":      shared.MysqlGeometryToEwktXnull,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
+	"xml":                    shared.CastToStringPrintQuotedLiteralXnull,
+	"smallmoney":             shared.PgMoneyToNumericXnull,
+	"hierarchyid":            shared.CastToBytesPrintPgByteaXnull,
+	"rowversion":             shared.CastToBytesPrintPgByteaXnull,
+}
+
+var PostgresqlConvertedValues = map[string]bool{
+	"SQL_BIT":        true,
+	"bit":            true,
+	"bit varying":    true,
+	"money":          true,
+	"mysql_geometry": true,
+	"smallmoney":     true,
+	"varbit":         true,
+}
+
+// PostgreSQL only has a geometry type when PostGIS is installed. Without it,
+// spatial columns are written as well-known text.
+var PostgresqlNoPostgisCreateFormatters = map[string]func(column *sql.ColumnType, terminator string) (string, error){
+	"mysql_geometry": shared.TextCreateFormatter,
+}
+
+var PostgresqlNoPostgisValFormatters = map[string]func(value interface{}, terminator string) (formattedValue string, err error){
+	"mysql_geometry": shared.MysqlGeometryToWktXnull,
 }
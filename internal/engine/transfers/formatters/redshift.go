@@ -0,0 +1,85 @@
+package formatters
+
+import (
+	"database/sql"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+// Redshift speaks PostgreSQL, but has no bytea, json, xml, uuid, array or bit
+// string types, so those are written as text. Backslashes escape in its string
+// literals.
+var RedshiftCreateFormatters = inheritCreateFormatters(PostgresqlCreateFormatters, map[string]func(column *sql.ColumnType, terminator string) (string, error){
+	"SQL_UNKNOWN_TYPE":    shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_CHAR":            shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_VARCHAR":         shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_LONGVARCHAR":     shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_WCHAR":           shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_WVARCHAR":        shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_WLONGVARCHAR":    shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_SIGNED_OFFSET":   shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_UNSIGNED_OFFSET": shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_SS_VARIANT":      shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_NUMERIC":         shared.RedshiftNumericCreateFormatter,
+	"SQL_DECIMAL":         shared.RedshiftNumericCreateFormatter,
+	"SQL_BINARY":          shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_VARBINARY":       shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_LONGVARBINARY":   shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_SS_UDT":          shared.RedshiftVarcharMaxCreateFormatter,
+	"SQL_GUID":            shared.RedshiftUuidCreateFormatter,
+	"SQL_SS_XML":          shared.RedshiftVarcharMaxCreateFormatter,
+	"uniqueidentifier":    shared.RedshiftUuidCreateFormatter,
+	"uuid":                shared.RedshiftUuidCreateFormatter,
+	"xml":                 shared.RedshiftVarcharMaxCreateFormatter,
+	"json":                shared.RedshiftVarcharMaxCreateFormatter,
+	"jsonb":               shared.RedshiftVarcharMaxCreateFormatter,
+	"array":               shared.RedshiftVarcharMaxCreateFormatter,
+	"interval":            shared.RedshiftVarcharMaxCreateFormatter,
+	"inet":                shared.RedshiftVarcharMaxCreateFormatter,
+	"cidr":                shared.RedshiftVarcharMaxCreateFormatter,
+	"money":               shared.MoneyNumericCreateFormatter,
+	"bit":                 shared.RedshiftVarcharMaxCreateFormatter,
+	"varbit":              shared.RedshiftVarcharMaxCreateFormatter,
+	"bit varying":         shared.RedshiftVarcharMaxCreateFormatter,
+	"mysql_geometry":      shared.RedshiftVarcharMaxCreateFormatter,
+	"enum":                shared.RedshiftVarcharMaxCreateFormatter,
+	"set":                 shared.RedshiftVarcharMaxCreateFormatter,
+
+	// SQL Server
+	"sql_variant": shared.RedshiftVarcharMaxCreateFormatter,
+	"hierarchyid": shared.RedshiftVarcharMaxCreateFormatter,
+	"rowversion":  shared.RedshiftVarcharMaxCreateFormatter,
+})
+
+var RedshiftValFormatters = inheritValFormatters(PostgresqlValFormatters, map[string]func(value interface{}, terminator string) (formattedValue string, err error){
+	"SQL_UNKNOWN_TYPE":    shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_CHAR":            shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_VARCHAR":         shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_LONGVARCHAR":     shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_WCHAR":           shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_WVARCHAR":        shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_WLONGVARCHAR":    shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_SIGNED_OFFSET":   shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_UNSIGNED_OFFSET": shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_SS_VARIANT":      shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_BINARY":          shared.CastToBytesCastToStringPrintQuotedHexXnull,
+	"SQL_VARBINARY":       shared.CastToBytesCastToStringPrintQuotedHexXnull,
+	"SQL_LONGVARBINARY":   shared.CastToBytesCastToStringPrintQuotedHexXnull,
+	"SQL_SS_UDT":          shared.CastToBytesCastToStringPrintQuotedHexXnull,
+	"SQL_SS_XML":          shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"json":                shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"jsonb":               shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"array":               shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"bit":                 shared.PgBitStringPrintTextXnull,
+	"varbit":              shared.PgBitStringPrintTextXnull,
+	"bit varying":         shared.PgBitStringPrintTextXnull,
+	"mysql_geometry":      shared.MysqlGeometryToWktXnull,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"xml":                    shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"hierarchyid":            shared.CastToBytesCastToStringPrintQuotedHexXnull,
+	"rowversion":             shared.CastToBytesCastToStringPrintQuotedHexXnull,
+})
+
+var RedshiftConvertedValues = inheritConvertedValues(PostgresqlConvertedValues, map[string]bool{})
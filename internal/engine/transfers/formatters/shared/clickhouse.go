@@ -0,0 +1,168 @@
+package shared
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// clickhouseColumn wraps the column's type in Nullable unless the source
+// reports that it can't hold nulls.
+func clickhouseColumn(column *sql.ColumnType, typeName, terminator string) (string, error) {
+	nullable, ok := column.Nullable()
+	if !ok || nullable {
+		typeName = fmt.Sprintf("Nullable(%v)", typeName)
+	}
+	return fmt.Sprintf("%v %v%v", column.Name(), typeName, terminator), nil
+}
+
+func ClickhouseStringCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "String", terminator)
+}
+
+func ClickhouseLowCardinalityStringCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	// Nullable goes inside LowCardinality, not around it
+	nullable, ok := column.Nullable()
+	if !ok || nullable {
+		return fmt.Sprintf("%v LowCardinality(Nullable(String))%v", column.Name(), terminator), nil
+	}
+	return fmt.Sprintf("%v LowCardinality(String)%v", column.Name(), terminator), nil
+}
+
+// ClickhouseDecimalCreateFormatter falls back to Float64 for decimals wider
+// than ClickHouse's 76 digits, or without a declared precision.
+func ClickhouseDecimalCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	precision, scale, ok := column.DecimalSize()
+	if !ok || precision <= 0 || precision > 76 || scale < 0 || scale > precision {
+		return clickhouseColumn(column, "Float64", terminator)
+	}
+	return clickhouseColumn(column, fmt.Sprintf("Decimal(%v,%v)", precision, scale), terminator)
+}
+
+func ClickhouseMoneyDecimalCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "Decimal(19,4)", terminator)
+}
+
+func ClickhouseInt8CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "Int8", terminator)
+}
+
+func ClickhouseInt16CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "Int16", terminator)
+}
+
+func ClickhouseInt32CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "Int32", terminator)
+}
+
+func ClickhouseInt64CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "Int64", terminator)
+}
+
+func ClickhouseUInt8CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "UInt8", terminator)
+}
+
+func ClickhouseUInt16CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "UInt16", terminator)
+}
+
+func ClickhouseUInt32CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "UInt32", terminator)
+}
+
+func ClickhouseUInt64CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "UInt64", terminator)
+}
+
+func ClickhouseFloat32CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "Float32", terminator)
+}
+
+func ClickhouseFloat64CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "Float64", terminator)
+}
+
+func ClickhouseBoolCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "Bool", terminator)
+}
+
+func ClickhouseUuidCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "UUID", terminator)
+}
+
+// ClickhouseDateCreateFormatter uses Date32, since Date only reaches back to
+// 1970.
+func ClickhouseDateCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "Date32", terminator)
+}
+
+func ClickhouseDateTimeCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "DateTime64(6)", terminator)
+}
+
+func ClickhouseDateTimeUtcCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return clickhouseColumn(column, "DateTime64(7, 'UTC')", terminator)
+}
+
+// ClickhouseTableEngine returns the engine clause that ends a ClickHouse
+// create table command. Tables are MergeTree unless another engine is asked
+// for, and are unordered unless order by columns are given.
+func ClickhouseTableEngine(engine string, orderBy []string) string {
+	if engine == "" {
+		engine = "MergeTree"
+	}
+	if len(orderBy) == 0 {
+		return fmt.Sprintf(" engine = %v order by tuple()", engine)
+	}
+	// source columns are usually nullable, which sorting keys don't allow by default
+	return fmt.Sprintf(" engine = %v order by (%v) settings allow_nullable_key = 1", engine, strings.Join(orderBy, ","))
+}
+
+func ClickhouseDateTimeXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valTime, ok := value.(time.Time)
+	if !ok {
+		return "", errors.New("ClickhouseDateTimeXnull unable to cast value to time")
+	}
+	return fmt.Sprintf("'%v'%v", valTime.Format("2006-01-02 15:04:05.000000"), terminator), nil
+}
+
+func ClickhouseDateTimeUtcXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valTime, ok := value.(time.Time)
+	if !ok {
+		return "", errors.New("ClickhouseDateTimeUtcXnull unable to cast value to time")
+	}
+	return fmt.Sprintf("'%v'%v", valTime.UTC().Format("2006-01-02 15:04:05.0000000"), terminator), nil
+}
+
+// ClickhouseDateTimeTextXnull converts timestamps read as text, such as SQL
+// Server datetimeoffsets, which ClickHouse can't parse as plain literals.
+func ClickhouseDateTimeTextXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valString, ok := valueToString(value)
+	if !ok {
+		return "", errors.New("ClickhouseDateTimeTextXnull unable to cast value to string")
+	}
+	return fmt.Sprintf("parseDateTime64BestEffort('%v', 7, 'UTC')%v", escapedLiteralReplacer.Replace(valString), terminator), nil
+}
+
+func ClickhouseBinaryXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valBytes, ok := value.([]byte)
+	if !ok {
+		return "", errors.New("ClickhouseBinaryXnull unable to cast value to bytes")
+	}
+	return fmt.Sprintf("unhex('%x')%v", valBytes, terminator), nil
+}
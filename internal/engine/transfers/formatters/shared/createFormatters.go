@@ -121,3 +121,116 @@ func UniqueIdentifierCreateFormatter(column *sql.ColumnType, terminator string)
 func XmlCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
 	return fmt.Sprintf("%v xml%v", column.Name(), terminator), nil
 }
+
+// NativeCreateFormatter keeps the source's own type, for copies between
+// databases of the same kind.
+func NativeCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	_, typeName := TypeNames(column.DatabaseTypeName())
+	if typeName == "" {
+		return "", fmt.Errorf("no native type name for column %v", column.Name())
+	}
+	return fmt.Sprintf("%v %v%v", column.Name(), typeName, terminator), nil
+}
+
+func JsonCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v json%v", column.Name(), terminator), nil
+}
+
+func VariantCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v variant%v", column.Name(), terminator), nil
+}
+
+func NVarcharMaxCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v nvarchar(max)%v", column.Name(), terminator), nil
+}
+
+func VarcharMaxCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v varchar(max)%v", column.Name(), terminator), nil
+}
+
+func VarbinaryMaxCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v varbinary(max)%v", column.Name(), terminator), nil
+}
+
+// MoneyNumericCreateFormatter fits both PostgreSQL money, with 17 integer
+// digits, and SQL Server money, with 4 decimal places.
+func MoneyNumericCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v numeric(21,4)%v", column.Name(), terminator), nil
+}
+
+func PgBitStringCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	_, typeName := TypeNames(column.DatabaseTypeName())
+	length, ok := column.Length()
+	if ok && length > 0 {
+		return fmt.Sprintf("%v %v(%v)%v", column.Name(), typeName, length, terminator), nil
+	}
+	return fmt.Sprintf("%v %v%v", column.Name(), typeName, terminator), nil
+}
+
+func MysqlBitStringCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	length, ok := column.Length()
+	if ok && length > 0 && length <= 64 {
+		return fmt.Sprintf("%v bit(%v)%v", column.Name(), length, terminator), nil
+	}
+	return fmt.Sprintf("%v longblob%v", column.Name(), terminator), nil
+}
+
+func GeometryCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v geometry%v", column.Name(), terminator), nil
+}
+
+func UnsignedBigIntNumericCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v numeric(20,0)%v", column.Name(), terminator), nil
+}
+
+func PgMoneyCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	sqlType, _ := TypeNames(column.DatabaseTypeName())
+	switch sqlType {
+	case "SQL_DECIMAL", "SQL_NUMERIC":
+		// SQL Server money, which has a different range
+		return MoneyNumericCreateFormatter(column, terminator)
+	}
+	return fmt.Sprintf("%v money%v", column.Name(), terminator), nil
+}
+
+func TimestamptzCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v timestamptz%v", column.Name(), terminator), nil
+}
+
+func TimestampTzCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v timestamp_tz%v", column.Name(), terminator), nil
+}
+
+func DatetimeoffsetCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v datetimeoffset%v", column.Name(), terminator), nil
+}
+
+// DatetimeoffsetVarcharCreateFormatter is wide enough for a SQL Server
+// datetimeoffset's text, such as 2005-06-12 11:40:17.6320000 +01:00.
+func DatetimeoffsetVarcharCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v varchar(34)%v", column.Name(), terminator), nil
+}
+
+func SqlVariantCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v sql_variant%v", column.Name(), terminator), nil
+}
+
+func HierarchyidCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v hierarchyid%v", column.Name(), terminator), nil
+}
+
+func RowversionBinaryCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v binary(8)%v", column.Name(), terminator), nil
+}
+
+func IntegerCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v integer%v", column.Name(), terminator), nil
+}
+
+func RealCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v real%v", column.Name(), terminator), nil
+}
+
+func BlobCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v blob%v", column.Name(), terminator), nil
+}
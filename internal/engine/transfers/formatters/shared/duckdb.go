@@ -0,0 +1,39 @@
+package shared
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DuckdbDecimalCreateFormatter falls back to double for decimals wider than
+// DuckDB's 38 digits, or without a declared precision.
+func DuckdbDecimalCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	precision, scale, ok := column.DecimalSize()
+	if !ok || precision <= 0 || precision > 38 || scale < 0 || scale > precision {
+		return fmt.Sprintf("%v double%v", column.Name(), terminator), nil
+	}
+	return fmt.Sprintf("%v decimal(%v,%v)%v", column.Name(), precision, scale, terminator), nil
+}
+
+func UbigintCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v ubigint%v", column.Name(), terminator), nil
+}
+
+// DuckdbBlobXnull writes bytes as a blob literal, which DuckDB reads as
+// escaped bytes rather than hex.
+func DuckdbBlobXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valBytes, ok := value.([]byte)
+	if !ok {
+		return "", errors.New("DuckdbBlobXnull unable to cast value to bytes")
+	}
+	var b strings.Builder
+	for _, valByte := range valBytes {
+		fmt.Fprintf(&b, "\\x%02X", valByte)
+	}
+	return fmt.Sprintf("'%v'::blob%v", b.String(), terminator), nil
+}
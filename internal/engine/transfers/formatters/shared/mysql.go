@@ -0,0 +1,290 @@
+package shared
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MySQL spatial values are stored as a 4 byte little endian SRID followed by
+// the geometry in well-known binary (WKB).
+var mysqlSpatialTypes = map[string]bool{
+	"geometry":           true,
+	"point":              true,
+	"linestring":         true,
+	"polygon":            true,
+	"multipoint":         true,
+	"multilinestring":    true,
+	"multipolygon":       true,
+	"geometrycollection": true,
+	"geomcollection":     true,
+}
+
+var wkbTypeNames = map[uint32]string{
+	1: "POINT",
+	2: "LINESTRING",
+	3: "POLYGON",
+	4: "MULTIPOINT",
+	5: "MULTILINESTRING",
+	6: "MULTIPOLYGON",
+	7: "GEOMETRYCOLLECTION",
+}
+
+// MysqlGeometryToWkt splits a MySQL spatial value into its SRID and its
+// well-known text (WKT) representation.
+func MysqlGeometryToWkt(geometry []byte) (srid uint32, wkt string, err error) {
+	if len(geometry) < 4 {
+		return 0, "", errors.New("MysqlGeometryToWkt value too short to be a geometry")
+	}
+	srid = binary.LittleEndian.Uint32(geometry[:4])
+	wkt, err = WkbToWkt(geometry[4:])
+	return srid, wkt, err
+}
+
+// WkbToWkt converts a two dimensional well-known binary (WKB) geometry to
+// well-known text (WKT).
+func WkbToWkt(wkb []byte) (string, error) {
+	r := wkbReader{wkb: wkb}
+	var b strings.Builder
+	err := r.readGeometry(&b, true)
+	if err != nil {
+		return "", err
+	}
+	if r.pos != len(wkb) {
+		return "", errors.New("WkbToWkt unexpected trailing bytes in geometry")
+	}
+	return b.String(), nil
+}
+
+type wkbReader struct {
+	wkb       []byte
+	pos       int
+	byteOrder binary.ByteOrder
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if r.pos+4 > len(r.wkb) {
+		return 0, errors.New("WkbToWkt geometry ended unexpectedly")
+	}
+	v := r.byteOrder.Uint32(r.wkb[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *wkbReader) readPoint(b *strings.Builder) error {
+	if r.pos+16 > len(r.wkb) {
+		return errors.New("WkbToWkt geometry ended unexpectedly")
+	}
+	x := math.Float64frombits(r.byteOrder.Uint64(r.wkb[r.pos:]))
+	y := math.Float64frombits(r.byteOrder.Uint64(r.wkb[r.pos+8:]))
+	r.pos += 16
+	b.WriteString(strconv.FormatFloat(x, 'f', -1, 64))
+	b.WriteString(" ")
+	b.WriteString(strconv.FormatFloat(y, 'f', -1, 64))
+	return nil
+}
+
+func (r *wkbReader) readPoints(b *strings.Builder) error {
+	n, err := r.readUint32()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		b.WriteString("EMPTY")
+		return nil
+	}
+	b.WriteString("(")
+	for i := uint32(0); i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		err = r.readPoint(b)
+		if err != nil {
+			return err
+		}
+	}
+	b.WriteString(")")
+	return nil
+}
+
+func (r *wkbReader) readRings(b *strings.Builder) error {
+	n, err := r.readUint32()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		b.WriteString("EMPTY")
+		return nil
+	}
+	b.WriteString("(")
+	for i := uint32(0); i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		err = r.readPoints(b)
+		if err != nil {
+			return err
+		}
+	}
+	b.WriteString(")")
+	return nil
+}
+
+func (r *wkbReader) readGeometry(b *strings.Builder, withName bool) error {
+	if r.pos >= len(r.wkb) {
+		return errors.New("WkbToWkt geometry ended unexpectedly")
+	}
+	switch r.wkb[r.pos] {
+	case 0:
+		r.byteOrder = binary.BigEndian
+	case 1:
+		r.byteOrder = binary.LittleEndian
+	default:
+		return fmt.Errorf("WkbToWkt invalid byte order %v", r.wkb[r.pos])
+	}
+	r.pos++
+	geometryType, err := r.readUint32()
+	if err != nil {
+		return err
+	}
+	name, ok := wkbTypeNames[geometryType]
+	if !ok {
+		return fmt.Errorf("WkbToWkt unsupported geometry type %v", geometryType)
+	}
+
+	var body strings.Builder
+	err = r.readGeometryBody(&body, geometryType)
+	if err != nil {
+		return err
+	}
+	if withName {
+		b.WriteString(name)
+		// empty geometries are written as LINESTRING EMPTY, not LINESTRING()
+		if strings.HasPrefix(body.String(), "EMPTY") {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString(body.String())
+	return nil
+}
+
+func (r *wkbReader) readGeometryBody(b *strings.Builder, geometryType uint32) error {
+	switch geometryType {
+	case 1:
+		b.WriteString("(")
+		err := r.readPoint(b)
+		b.WriteString(")")
+		return err
+	case 2:
+		return r.readPoints(b)
+	case 3:
+		return r.readRings(b)
+	}
+
+	n, err := r.readUint32()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		b.WriteString("EMPTY")
+		return nil
+	}
+	b.WriteString("(")
+	for i := uint32(0); i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		// members of a multi geometry are written without their type name,
+		// while a collection names each member
+		err = r.readGeometry(b, geometryType == 7)
+		if err != nil {
+			return err
+		}
+	}
+	b.WriteString(")")
+	return nil
+}
+
+// MysqlEnumValues returns the values allowed by a MySQL enum or set column,
+// given its column_type from information_schema, such as enum('a','b').
+func MysqlEnumValues(columnType string) ([]string, error) {
+	open := strings.Index(columnType, "(")
+	if open < 0 || !strings.HasSuffix(columnType, ")") {
+		return nil, fmt.Errorf("MysqlEnumValues invalid column type %v", columnType)
+	}
+	list := columnType[open+1 : len(columnType)-1]
+	values := []string{}
+	for len(list) > 0 {
+		if list[0] != '\'' {
+			return nil, fmt.Errorf("MysqlEnumValues invalid column type %v", columnType)
+		}
+		var value strings.Builder
+		i := 1
+		for ; i < len(list); i++ {
+			if list[i] != '\'' {
+				value.WriteByte(list[i])
+				continue
+			}
+			if i+1 < len(list) && list[i+1] == '\'' {
+				value.WriteByte('\'')
+				i++
+				continue
+			}
+			break
+		}
+		if i >= len(list) {
+			return nil, fmt.Errorf("MysqlEnumValues invalid column type %v", columnType)
+		}
+		values = append(values, value.String())
+		list = strings.TrimPrefix(list[i+1:], ",")
+	}
+	return values, nil
+}
+
+func mysqlGeometryFromValue(value interface{}) (srid uint32, wkt string, err error) {
+	valBytes, ok := value.([]byte)
+	if !ok {
+		return 0, "", errors.New("MysqlGeometry unable to cast value to bytes")
+	}
+	return MysqlGeometryToWkt(valBytes)
+}
+
+func MysqlGeometryToWktXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	_, wkt, err := mysqlGeometryFromValue(value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("'%v'%v", wkt, terminator), nil
+}
+
+func MysqlGeometryToEwktXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	srid, wkt, err := mysqlGeometryFromValue(value)
+	if err != nil {
+		return "", err
+	}
+	if srid == 0 {
+		return fmt.Sprintf("'%v'%v", wkt, terminator), nil
+	}
+	return fmt.Sprintf("'SRID=%v;%v'%v", srid, wkt, terminator), nil
+}
+
+func MysqlGeometryToMysqlWkbXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valBytes, ok := value.([]byte)
+	if !ok || len(valBytes) < 4 {
+		return "", errors.New("MysqlGeometryToMysqlWkbXnull unable to cast value to geometry")
+	}
+	srid := binary.LittleEndian.Uint32(valBytes[:4])
+	return fmt.Sprintf("ST_GeomFromWKB(x'%x',%v)%v", valBytes[4:], srid, terminator), nil
+}
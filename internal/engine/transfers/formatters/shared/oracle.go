@@ -0,0 +1,192 @@
+package shared
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Oracle limits varchar2 columns and string literals to 4000 bytes, and raw
+// columns and hextoraw literals to 2000 bytes.
+const (
+	oracleMaxStringLength = 4000
+	oracleMaxRawLength    = 2000
+)
+
+func OracleVarchar2CreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	length, ok := column.Length()
+	if !ok || length <= 0 || length > oracleMaxStringLength {
+		return OracleClobCreateFormatter(column, terminator)
+	}
+	return fmt.Sprintf("%v varchar2(%v char)%v", column.Name(), length, terminator), nil
+}
+
+func OracleClobCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v clob%v", column.Name(), terminator), nil
+}
+
+func OracleNumberCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	precision, scale, ok := column.DecimalSize()
+	if !ok || precision <= 0 || precision > 38 || scale < 0 || scale > precision {
+		return fmt.Sprintf("%v number%v", column.Name(), terminator), nil
+	}
+	return fmt.Sprintf("%v number(%v,%v)%v", column.Name(), precision, scale, terminator), nil
+}
+
+func OracleBooleanCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v number(1)%v", column.Name(), terminator), nil
+}
+
+func BinaryFloatCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v binary_float%v", column.Name(), terminator), nil
+}
+
+func BinaryDoubleCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v binary_double%v", column.Name(), terminator), nil
+}
+
+func OracleTimestampCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v timestamp(9)%v", column.Name(), terminator), nil
+}
+
+func OracleTimestampTzCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v timestamp(9) with time zone%v", column.Name(), terminator), nil
+}
+
+// OracleTimeCreateFormatter stores times of day as text, since Oracle has no
+// type for them.
+func OracleTimeCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v varchar2(18)%v", column.Name(), terminator), nil
+}
+
+func OracleRawCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	length, ok := column.Length()
+	if !ok || length <= 0 || length > oracleMaxRawLength {
+		return OracleBlobCreateFormatter(column, terminator)
+	}
+	return fmt.Sprintf("%v raw(%v)%v", column.Name(), length, terminator), nil
+}
+
+func OracleBlobCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v blob%v", column.Name(), terminator), nil
+}
+
+func OracleUuidCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v varchar2(36)%v", column.Name(), terminator), nil
+}
+
+func OracleRowversionCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v raw(8)%v", column.Name(), terminator), nil
+}
+
+// oracleTextXnull writes text as a string literal. Oracle treats empty strings
+// as null, so they are written as null, and text longer than a string literal
+// allows is written as concatenated to_clob calls.
+func oracleTextXnull(replacer *strings.Replacer) func(value interface{}, terminator string) (formattedValue string, err error) {
+	return func(value interface{}, terminator string) (formattedValue string, err error) {
+		if value == nil {
+			return fmt.Sprintf("null%v", terminator), nil
+		}
+		valString, ok := valueToString(value)
+		if !ok {
+			return "", errors.New("OracleTextXnull unable to cast value to string")
+		}
+		if valString == "" {
+			return fmt.Sprintf("null%v", terminator), nil
+		}
+		if len(valString) <= oracleMaxStringLength {
+			return fmt.Sprintf("'%v'%v", replacer.Replace(valString), terminator), nil
+		}
+		chunks := []string{}
+		for _, chunk := range OracleSplitText(valString, oracleMaxStringLength) {
+			chunks = append(chunks, fmt.Sprintf("to_clob('%v')", replacer.Replace(chunk)))
+		}
+		return fmt.Sprintf("%v%v", strings.Join(chunks, "||"), terminator), nil
+	}
+}
+
+var (
+	OracleTextXnull    = oracleTextXnull(dbValReplacer)
+	OracleLiteralXnull = oracleTextXnull(literalReplacer)
+)
+
+// OracleSplitText splits text into pieces of at most maxLength bytes without
+// splitting a multi byte character.
+func OracleSplitText(text string, maxLength int) []string {
+	chunks := []string{}
+	for len(text) > maxLength {
+		end := maxLength
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		chunks = append(chunks, text[:end])
+		text = text[end:]
+	}
+	return append(chunks, text)
+}
+
+func OracleRawXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valBytes, ok := value.([]byte)
+	if !ok {
+		return "", errors.New("OracleRawXnull unable to cast value to bytes")
+	}
+	if len(valBytes) == 0 {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	if len(valBytes) > oracleMaxRawLength {
+		return "", fmt.Errorf("OracleRawXnull binary values over %v bytes can't be written as literals", oracleMaxRawLength)
+	}
+	return fmt.Sprintf("hextoraw('%x')%v", valBytes, terminator), nil
+}
+
+func OracleDateXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valTime, ok := value.(time.Time)
+	if !ok {
+		return "", errors.New("OracleDateXnull unable to cast value to time")
+	}
+	return fmt.Sprintf("to_date('%v','YYYY-MM-DD')%v", valTime.Format("2006-01-02"), terminator), nil
+}
+
+func OracleTimestampXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valTime, ok := value.(time.Time)
+	if !ok {
+		return "", errors.New("OracleTimestampXnull unable to cast value to time")
+	}
+	return fmt.Sprintf("to_timestamp('%v','YYYY-MM-DD HH24:MI:SS.FF9')%v", valTime.Format("2006-01-02 15:04:05.000000000"), terminator), nil
+}
+
+func OracleTimestampTzXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valTime, ok := value.(time.Time)
+	if !ok {
+		return "", errors.New("OracleTimestampTzXnull unable to cast value to time")
+	}
+	return fmt.Sprintf("to_timestamp_tz('%v','YYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM')%v", valTime.Format("2006-01-02 15:04:05.000000000 -07:00"), terminator), nil
+}
+
+// OracleTimestampTzTextXnull converts timestamps read as text, such as SQL
+// Server datetimeoffsets.
+func OracleTimestampTzTextXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valString, ok := valueToString(value)
+	if !ok {
+		return "", errors.New("OracleTimestampTzTextXnull unable to cast value to string")
+	}
+	return fmt.Sprintf("to_timestamp_tz('%v','YYYY-MM-DD HH24:MI:SS.FF TZH:TZM')%v", dbValReplacer.Replace(valString), terminator), nil
+}
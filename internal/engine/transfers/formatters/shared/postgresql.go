@@ -0,0 +1,477 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Element kinds understood by PgArrayToJson.
+const (
+	PgArrayText    = "text"
+	PgArrayNumeric = "numeric"
+	PgArrayBool    = "bool"
+)
+
+// PgArrayToJson converts a PostgreSQL array literal, such as
+// {1,2,NULL} or {{"a b",c},{d,e}}, to a JSON array.
+func PgArrayToJson(literal string, elementKind string) (string, error) {
+	literal = strings.TrimSpace(literal)
+	// drop explicit dimensions, as in [0:1]={1,2}
+	if strings.HasPrefix(literal, "[") {
+		i := strings.Index(literal, "=")
+		if i == -1 {
+			return "", fmt.Errorf("invalid array literal %v", literal)
+		}
+		literal = strings.TrimSpace(literal[i+1:])
+	}
+	p := pgArrayParser{literal: literal, elementKind: elementKind}
+	var b strings.Builder
+	err := p.parseArray(&b)
+	if err != nil {
+		return "", err
+	}
+	if p.pos != len(p.literal) {
+		return "", fmt.Errorf("unexpected trailing characters in array literal %v", literal)
+	}
+	return b.String(), nil
+}
+
+type pgArrayParser struct {
+	literal     string
+	elementKind string
+	pos         int
+}
+
+func (p *pgArrayParser) skipSpace() {
+	for p.pos < len(p.literal) && (p.literal[p.pos] == ' ' || p.literal[p.pos] == '\t' || p.literal[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *pgArrayParser) parseArray(b *strings.Builder) error {
+	if p.pos >= len(p.literal) || p.literal[p.pos] != '{' {
+		return fmt.Errorf("expected { at position %v of array literal %v", p.pos, p.literal)
+	}
+	p.pos++
+	b.WriteString("[")
+	p.skipSpace()
+	if p.pos < len(p.literal) && p.literal[p.pos] == '}' {
+		p.pos++
+		b.WriteString("]")
+		return nil
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.literal) {
+			return fmt.Errorf("unterminated array literal %v", p.literal)
+		}
+		switch p.literal[p.pos] {
+		case '{':
+			err := p.parseArray(b)
+			if err != nil {
+				return err
+			}
+		case '"':
+			element, err := p.parseQuoted()
+			if err != nil {
+				return err
+			}
+			err = p.writeElement(b, element, true)
+			if err != nil {
+				return err
+			}
+		default:
+			start := p.pos
+			for p.pos < len(p.literal) && p.literal[p.pos] != ',' && p.literal[p.pos] != '}' {
+				p.pos++
+			}
+			err := p.writeElement(b, strings.TrimSpace(p.literal[start:p.pos]), false)
+			if err != nil {
+				return err
+			}
+		}
+		p.skipSpace()
+		if p.pos >= len(p.literal) {
+			return fmt.Errorf("unterminated array literal %v", p.literal)
+		}
+		switch p.literal[p.pos] {
+		case ',':
+			p.pos++
+			b.WriteString(",")
+		case '}':
+			p.pos++
+			b.WriteString("]")
+			return nil
+		default:
+			return fmt.Errorf("unexpected %q at position %v of array literal %v", p.literal[p.pos], p.pos, p.literal)
+		}
+	}
+}
+
+func (p *pgArrayParser) parseQuoted() (string, error) {
+	var element strings.Builder
+	p.pos++
+	for p.pos < len(p.literal) {
+		c := p.literal[p.pos]
+		switch c {
+		case '\\':
+			p.pos++
+			if p.pos >= len(p.literal) {
+				return "", fmt.Errorf("unterminated array literal %v", p.literal)
+			}
+			element.WriteByte(p.literal[p.pos])
+		case '"':
+			p.pos++
+			return element.String(), nil
+		default:
+			element.WriteByte(c)
+		}
+		p.pos++
+	}
+	return "", fmt.Errorf("unterminated quoted element in array literal %v", p.literal)
+}
+
+func (p *pgArrayParser) writeElement(b *strings.Builder, element string, quoted bool) error {
+	if !quoted && strings.EqualFold(element, "null") {
+		b.WriteString("null")
+		return nil
+	}
+	switch p.elementKind {
+	case PgArrayNumeric:
+		// NaN and Infinity have no JSON number representation
+		if _, ok := new(big.Float).SetString(element); ok && json.Valid([]byte(element)) {
+			b.WriteString(element)
+			return nil
+		}
+	case PgArrayBool:
+		switch element {
+		case "t", "true":
+			b.WriteString("true")
+			return nil
+		case "f", "false":
+			b.WriteString("false")
+			return nil
+		}
+	}
+	encoded, err := json.Marshal(element)
+	if err != nil {
+		return err
+	}
+	b.Write(encoded)
+	return nil
+}
+
+// PgIntervalToIso8601 converts a PostgreSQL interval in the default
+// "postgres" output style, such as "1 year 2 mons -3 days 04:05:06.5",
+// to an ISO-8601 duration in the form PostgreSQL itself uses for
+// IntervalStyle iso_8601 ("P1Y2M-3DT4H5M6.5S"). Values that are already
+// ISO-8601 are returned unchanged.
+func PgIntervalToIso8601(interval string) (string, error) {
+	interval = strings.TrimSpace(interval)
+	if strings.HasPrefix(interval, "P") || strings.HasPrefix(interval, "-P") {
+		return interval, nil
+	}
+	interval = strings.TrimPrefix(interval, "@ ")
+	ago := strings.HasSuffix(interval, " ago")
+	interval = strings.TrimSuffix(interval, " ago")
+
+	var dateBuilder, timeBuilder strings.Builder
+	fields := strings.Fields(interval)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.Contains(field, ":") {
+			err := writeIsoTime(&timeBuilder, field, ago)
+			if err != nil {
+				return "", fmt.Errorf("invalid interval %v: %v", interval, err)
+			}
+			continue
+		}
+		if i+1 >= len(fields) {
+			return "", fmt.Errorf("invalid interval %v: missing unit after %v", interval, field)
+		}
+		number := field
+		if ago {
+			number = negateNumber(number)
+		}
+		if _, err := strconv.ParseFloat(number, 64); err != nil {
+			return "", fmt.Errorf("invalid interval %v: %v", interval, err)
+		}
+		number = strings.TrimPrefix(number, "+")
+		unit := strings.TrimRight(fields[i+1], "s")
+		i++
+		switch unit {
+		case "year", "yr":
+			dateBuilder.WriteString(number + "Y")
+		case "mon", "month":
+			dateBuilder.WriteString(number + "M")
+		case "day":
+			dateBuilder.WriteString(number + "D")
+		case "hour", "hr":
+			timeBuilder.WriteString(number + "H")
+		case "min", "minute":
+			timeBuilder.WriteString(number + "M")
+		case "sec", "second":
+			timeBuilder.WriteString(number + "S")
+		default:
+			return "", fmt.Errorf("invalid interval %v: unknown unit %v", interval, fields[i])
+		}
+	}
+
+	if dateBuilder.Len() == 0 && timeBuilder.Len() == 0 {
+		return "PT0S", nil
+	}
+	iso := "P" + dateBuilder.String()
+	if timeBuilder.Len() > 0 {
+		iso = iso + "T" + timeBuilder.String()
+	}
+	return iso, nil
+}
+
+func writeIsoTime(b *strings.Builder, hms string, negate bool) error {
+	sign := ""
+	switch {
+	case strings.HasPrefix(hms, "-"):
+		sign = "-"
+		hms = hms[1:]
+	case strings.HasPrefix(hms, "+"):
+		hms = hms[1:]
+	}
+	if negate {
+		if sign == "-" {
+			sign = ""
+		} else {
+			sign = "-"
+		}
+	}
+	parts := strings.Split(hms, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("invalid time %v", hms)
+	}
+	units := []string{"H", "M", "S"}
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return err
+		}
+		if value == 0 {
+			continue
+		}
+		if i == 2 && strings.Contains(part, ".") {
+			part = strings.TrimRight(strings.TrimRight(part, "0"), ".")
+		}
+		part = strings.TrimLeft(part, "0")
+		if strings.HasPrefix(part, ".") || part == "" {
+			part = "0" + part
+		}
+		b.WriteString(sign + part + units[i])
+	}
+	return nil
+}
+
+func negateNumber(number string) string {
+	switch {
+	case strings.HasPrefix(number, "-"):
+		return number[1:]
+	case strings.HasPrefix(number, "+"):
+		return "-" + number[1:]
+	}
+	return "-" + number
+}
+
+// PgBitStringToBytes packs a bit string such as "0101" into bytes, most
+// significant bit first, padding the last byte with zeros.
+func PgBitStringToBytes(bits string) ([]byte, error) {
+	bytes := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		switch bit {
+		case '0':
+		case '1':
+			bytes[i/8] |= 1 << (7 - uint(i%8))
+		default:
+			return nil, fmt.Errorf("invalid bit string %v", bits)
+		}
+	}
+	return bytes, nil
+}
+
+// pgMoneyRX matches money, once its sign and currency symbol are removed,
+// as PostgreSQL outputs it with lc_monetary set to C or an English locale.
+var pgMoneyRX = regexp.MustCompile(`^(\d{1,3}(,\d{3})*|\d+)(\.\d+)?$`)
+
+// PgMoneyToNumeric converts a PostgreSQL money value, such as "$1,234.50"
+// or "-$0.99", to a plain numeric string. Money is output in the format of
+// the server's lc_monetary, and values in other formats, such as
+// "1.234,50 €", are rejected rather than guessed at. Cast the column to
+// numeric in the source query for those locales.
+func PgMoneyToNumeric(money string) (string, error) {
+	numeric := strings.TrimSpace(money)
+	negative := false
+	if strings.HasPrefix(numeric, "(") && strings.HasSuffix(numeric, ")") {
+		negative = true
+		numeric = numeric[1 : len(numeric)-1]
+	}
+	if strings.HasPrefix(numeric, "-") {
+		negative = !negative
+		numeric = numeric[1:]
+	}
+	numeric = strings.TrimPrefix(numeric, "$")
+	if strings.HasPrefix(numeric, "-") {
+		negative = !negative
+		numeric = numeric[1:]
+	}
+	if !pgMoneyRX.MatchString(numeric) {
+		return "", fmt.Errorf("money value %q is not in the C locale's format, set lc_monetary to C or cast the column to numeric", money)
+	}
+	numeric = strings.ReplaceAll(numeric, ",", "")
+	if negative {
+		numeric = "-" + numeric
+	}
+	return numeric, nil
+}
+
+func valueToString(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case []byte:
+		return string(value), true
+	case string:
+		return value, true
+	}
+	return "", false
+}
+
+var (
+	literalReplacer = strings.NewReplacer(`'`, `''`)
+	// for databases where backslashes in string literals are escapes
+	escapedLiteralReplacer = strings.NewReplacer(`'`, `''`, `\`, `\\`)
+)
+
+func castToStringPrintQuotedLiteralXnull(replacer *strings.Replacer) func(value interface{}, terminator string) (formattedValue string, err error) {
+	return func(value interface{}, terminator string) (formattedValue string, err error) {
+		if value == nil {
+			return fmt.Sprintf("null%v", terminator), nil
+		}
+		valString, ok := valueToString(value)
+		if !ok {
+			return "", errors.New("CastToStringPrintQuotedLiteralXnull unable to cast value to string")
+		}
+		return fmt.Sprintf("'%v'%v", replacer.Replace(valString), terminator), nil
+	}
+}
+
+// CastToStringPrintQuotedLiteralXnull quotes values that must be kept exactly
+// as they are, such as JSON documents and array literals.
+var (
+	CastToStringPrintQuotedLiteralXnull        = castToStringPrintQuotedLiteralXnull(literalReplacer)
+	CastToStringPrintQuotedEscapedLiteralXnull = castToStringPrintQuotedLiteralXnull(escapedLiteralReplacer)
+)
+
+func pgArrayToJsonXnull(elementKind string, replacer *strings.Replacer) func(value interface{}, terminator string) (formattedValue string, err error) {
+	return func(value interface{}, terminator string) (formattedValue string, err error) {
+		if value == nil {
+			return fmt.Sprintf("null%v", terminator), nil
+		}
+		valString, ok := valueToString(value)
+		if !ok {
+			return "", errors.New("PgArrayToJsonXnull unable to cast value to string")
+		}
+		valJson, err := PgArrayToJson(valString, elementKind)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("'%v'%v", replacer.Replace(valJson), terminator), nil
+	}
+}
+
+var (
+	PgTextArrayToJsonXnull           = pgArrayToJsonXnull(PgArrayText, literalReplacer)
+	PgNumericArrayToJsonXnull        = pgArrayToJsonXnull(PgArrayNumeric, literalReplacer)
+	PgBoolArrayToJsonXnull           = pgArrayToJsonXnull(PgArrayBool, literalReplacer)
+	PgTextArrayToEscapedJsonXnull    = pgArrayToJsonXnull(PgArrayText, escapedLiteralReplacer)
+	PgNumericArrayToEscapedJsonXnull = pgArrayToJsonXnull(PgArrayNumeric, escapedLiteralReplacer)
+	PgBoolArrayToEscapedJsonXnull    = pgArrayToJsonXnull(PgArrayBool, escapedLiteralReplacer)
+)
+
+func PgIntervalToIso8601Xnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valString, ok := valueToString(value)
+	if !ok {
+		return "", errors.New("PgIntervalToIso8601Xnull unable to cast value to string")
+	}
+	valIso, err := PgIntervalToIso8601(valString)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("'%v'%v", valIso, terminator), nil
+}
+
+func PgMoneyToNumericXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	if valString, ok := valueToString(value); ok {
+		valNumeric, err := PgMoneyToNumeric(valString)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v%v", valNumeric, terminator), nil
+	}
+	return fmt.Sprintf("%v%v", value, terminator), nil
+}
+
+func PgBitStringPrintQuotedXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valString, ok := valueToString(value)
+	if !ok {
+		return "", errors.New("PgBitStringPrintQuotedXnull unable to cast value to string")
+	}
+	return fmt.Sprintf("B'%v'%v", valString, terminator), nil
+}
+
+func PgBitStringPrintMysqlBitXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valString, ok := valueToString(value)
+	if !ok {
+		return "", errors.New("PgBitStringPrintMysqlBitXnull unable to cast value to string")
+	}
+	if valString == "" {
+		return fmt.Sprintf("''%v", terminator), nil
+	}
+	return fmt.Sprintf("b'%v'%v", valString, terminator), nil
+}
+
+func PgBitStringPrintTextXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valString, ok := valueToString(value)
+	if !ok {
+		return "", errors.New("PgBitStringPrintTextXnull unable to cast value to string")
+	}
+	return fmt.Sprintf("'%v'%v", valString, terminator), nil
+}
+
+func PgBitStringPrintQuotedHexXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valString, ok := valueToString(value)
+	if !ok {
+		return "", errors.New("PgBitStringPrintQuotedHexXnull unable to cast value to string")
+	}
+	valBytes, err := PgBitStringToBytes(valString)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("'%x'%v", valBytes, terminator), nil
+}
@@ -0,0 +1,26 @@
+package shared
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// RedshiftVarcharMaxCreateFormatter is used for text, since Redshift's text is
+// only varchar(256).
+func RedshiftVarcharMaxCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v varchar(max)%v", column.Name(), terminator), nil
+}
+
+// RedshiftNumericCreateFormatter falls back to double precision for decimals
+// wider than Redshift's 38 digits, since a bare numeric is numeric(18,0).
+func RedshiftNumericCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	precision, scale, ok := column.DecimalSize()
+	if !ok || precision <= 0 || precision > 38 || scale < 0 || scale > precision {
+		return fmt.Sprintf("%v double precision%v", column.Name(), terminator), nil
+	}
+	return fmt.Sprintf("%v numeric(%v,%v)%v", column.Name(), precision, scale, terminator), nil
+}
+
+func RedshiftUuidCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v char(36)%v", column.Name(), terminator), nil
+}
@@ -0,0 +1,12 @@
+package shared
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SqliteNumericCreateFormatter gives decimals SQLite's numeric affinity. SQLite
+// ignores the precision and scale of a declared type, so none are written.
+func SqliteNumericCreateFormatter(column *sql.ColumnType, terminator string) (string, error) {
+	return fmt.Sprintf("%v numeric%v", column.Name(), terminator), nil
+}
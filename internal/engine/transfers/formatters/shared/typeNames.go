@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sqlpipe/odbc"
+)
+
+var typeNamePrecision = regexp.MustCompile(`\s*\(\d+(\s*,\s*\d+)?\)`)
+
+// TypeNames splits a column's database type name into its generic ODBC type
+// and its normalized native type name. PostgreSQL array types such as "_int4"
+// are reported as "int4[]", MySQL spatial types, which are read as binary, as
+// "mysql_geometry" and SQL Server rowversion columns, which it calls
+// "timestamp", as "rowversion". Other geometry types, such as PostGIS's,
+// keep their own names. Precisions in names such as Oracle's "timestamp(6)
+// with time zone" are dropped. Booleans and MySQL bit(n) columns are left to
+// the generic mapping, since PostgreSQL uses "bit" for bit strings, which are
+// read as text.
+func TypeNames(colDbType string) (sqlType, typeName string) {
+	sqlType, typeName = odbc.SplitDatabaseTypeName(colDbType)
+	if sqlType == "SQL_BIT" {
+		return sqlType, ""
+	}
+	typeName = strings.ToLower(strings.TrimSpace(typeName))
+	typeName = typeNamePrecision.ReplaceAllString(typeName, "")
+	if strings.HasPrefix(typeName, "_") {
+		typeName = typeName[1:] + "[]"
+	}
+	switch sqlType {
+	case "SQL_BINARY", "SQL_VARBINARY", "SQL_LONGVARBINARY":
+		if mysqlSpatialTypes[typeName] {
+			return sqlType, "mysql_geometry"
+		}
+		if typeName == "bit" {
+			return sqlType, ""
+		}
+		if typeName == "timestamp" {
+			return sqlType, "rowversion"
+		}
+	}
+	return sqlType, typeName
+}
+
+// Lookup finds the formatter for a column, trying its native type name first,
+// then "array" for array types, then its generic ODBC type.
+func Lookup[T any](formatters map[string]T, colDbType string) (formatter T, ok bool) {
+	key, ok := LookupKey(formatters, colDbType)
+	if !ok {
+		return formatter, false
+	}
+	return formatters[key], true
+}
+
+// LookupKey returns the key Lookup finds a column's formatter under.
+func LookupKey[T any](formatters map[string]T, colDbType string) (key string, ok bool) {
+	sqlType, typeName := TypeNames(colDbType)
+	if typeName != "" {
+		if _, ok = formatters[typeName]; ok {
+			return typeName, true
+		}
+		if strings.HasSuffix(typeName, "[]") {
+			if _, ok = formatters["array"]; ok {
+				return "array", true
+			}
+		}
+	}
+	_, ok = formatters[sqlType]
+	return sqlType, ok
+}
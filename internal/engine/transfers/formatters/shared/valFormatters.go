@@ -13,6 +13,9 @@ func RawXnull(value interface{}, terminator string) (formattedValue string, err
 	if value == nil {
 		return fmt.Sprintf("null%v", terminator), nil
 	}
+	if valBytes, ok := value.([]byte); ok {
+		return fmt.Sprintf("%v%v", string(valBytes), terminator), nil
+	}
 	return fmt.Sprintf("%v%v", value, terminator), nil
 }
 
@@ -128,3 +131,71 @@ func CastToBytesCastToStringPrintQuotedHexXnull(value interface{}, terminator st
 	}
 	return fmt.Sprintf("'%x'%v", string(valBytes), terminator), nil
 }
+
+func CastToTimeFormatToMssqlDatetimeoffsetStringXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valTime, ok := value.(time.Time)
+	if !ok {
+		return "", errors.New("CastToTimeFormatToMssqlDatetimeoffsetStringXnull unable to cast value to time")
+	}
+	// the driver returns times in the sqlpipe host's zone, so they are written
+	// in UTC to keep the host's offset out of the target
+	return fmt.Sprintf("'%v'%v", valTime.UTC().Format("2006-01-02 15:04:05.9999999 -07:00"), terminator), nil
+}
+
+func CastToBytesPrintPgByteaXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valBytes, ok := value.([]byte)
+	if !ok {
+		return "", errors.New("CastToBytesPrintPgByteaXnull unable to cast value to bytes")
+	}
+	return fmt.Sprintf("'\\x%x'%v", valBytes, terminator), nil
+}
+
+func CastToBytesPrintMssqlHexXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valBytes, ok := value.([]byte)
+	if !ok {
+		return "", errors.New("CastToBytesPrintMssqlHexXnull unable to cast value to bytes")
+	}
+	return fmt.Sprintf("0x%x%v", valBytes, terminator), nil
+}
+
+func CastToBytesPrintMysqlHexXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valBytes, ok := value.([]byte)
+	if !ok {
+		return "", errors.New("CastToBytesPrintMysqlHexXnull unable to cast value to bytes")
+	}
+	return fmt.Sprintf("x'%x'%v", valBytes, terminator), nil
+}
+
+func CastToTimeFormatToIsoDateStringXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valTime, ok := value.(time.Time)
+	if !ok {
+		return "", errors.New("CastToTimeFormatToIsoDateStringXnull unable to cast value to time")
+	}
+	return fmt.Sprintf("'%v'%v", valTime.Format("2006-01-02"), terminator), nil
+}
+
+func CastToTimeFormatToIsoTimestampStringXnull(value interface{}, terminator string) (formattedValue string, err error) {
+	if value == nil {
+		return fmt.Sprintf("null%v", terminator), nil
+	}
+	valTime, ok := value.(time.Time)
+	if !ok {
+		return "", errors.New("CastToTimeFormatToIsoTimestampStringXnull unable to cast value to time")
+	}
+	return fmt.Sprintf("'%v'%v", valTime.Format("2006-01-02 15:04:05.999999999"), terminator), nil
+}
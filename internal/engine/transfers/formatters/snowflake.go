@@ -38,6 +38,37 @@ var SnowflakeCreateFormatters = map[string]func(column *sql.ColumnType, terminat
 	"SQL_UNSIGNED_OFFSET": shared.TextCreateFormatter,
 	"SQL_SS_XML":          shared.TextCreateFormatter,
 	"SQL_SS_TIME2":        shared.TimeCreateFormatter,
+	"SQL_SS_VARIANT":      shared.VariantCreateFormatter,
+	"SQL_SS_UDT":          shared.BinaryCreateFormatter,
+	"json":                shared.VariantCreateFormatter,
+	"jsonb":               shared.VariantCreateFormatter,
+	"array":               shared.VariantCreateFormatter,
+	"interval":            shared.TextCreateFormatter,
+	"money":               shared.MoneyNumericCreateFormatter,
+	"bit":                 shared.BinaryCreateFormatter,
+	"varbit":              shared.BinaryCreateFormatter,
+	"bit varying":         shared.BinaryCreateFormatter,
+	"mysql_geometry":      shared.TextCreateFormatter,
+	"tinyint unsigned":    shared.SmallIntCreateFormatter,
+	"smallint unsigned":   shared.IntCreateFormatter,
+	"mediumint unsigned":  shared.IntCreateFormatter,
+	"int unsigned":        shared.BigIntCreateFormatter,
+	"integer unsigned":    shared.BigIntCreateFormatter,
+	"bigint unsigned":     shared.UnsignedBigIntNumericCreateFormatter,
+	"enum":                shared.VarcharCreateFormatter,
+	"set":                 shared.VarcharCreateFormatter,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.TimestampTzCreateFormatter,
+	"datetimeoffset":         shared.TimestampTzCreateFormatter,
+	"smallmoney":             shared.MoneyNumericCreateFormatter,
+	"sql_variant":            shared.VariantCreateFormatter,
+	"hierarchyid":            shared.BinaryCreateFormatter,
+	"rowversion":             shared.RowversionBinaryCreateFormatter,
+
+	// Oracle
+	"timestamp with time zone":       shared.TimestampTzCreateFormatter,
+	"timestamp with local time zone": shared.TimestampTzCreateFormatter,
 }
 
 var SnowflakeValFormatters = map[string]func(value interface{}, terminator string) (formattedValue string, err error){
@@ -70,6 +101,53 @@ var SnowflakeValFormatters = map[string]func(value interface{}, terminator strin
 	"SQL_GUID":            shared.QuotedXnull,
 	"SQL_SIGNED_OFFSET":   shared.CastToBytesCastToStringPrintQuotedXnull,
 	"SQL_UNSIGNED_OFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
-	"SQL_SS_XML":          shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_XML":          shared.CastToStringPrintQuotedEscapedLiteralXnull,
 	"SQL_SS_TIME2":        shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_VARIANT":      shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"SQL_SS_UDT":          shared.CastToBytesCastToStringPrintQuotedHexXnull,
+	"json":                shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"jsonb":               shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"array":               shared.PgTextArrayToEscapedJsonXnull,
+	"int2[]":              shared.PgNumericArrayToEscapedJsonXnull,
+	"int4[]":              shared.PgNumericArrayToEscapedJsonXnull,
+	"int8[]":              shared.PgNumericArrayToEscapedJsonXnull,
+	"float4[]":            shared.PgNumericArrayToEscapedJsonXnull,
+	"float8[]":            shared.PgNumericArrayToEscapedJsonXnull,
+	"numeric[]":           shared.PgNumericArrayToEscapedJsonXnull,
+	"oid[]":               shared.PgNumericArrayToEscapedJsonXnull,
+	"bool[]":              shared.PgBoolArrayToEscapedJsonXnull,
+	"interval":            shared.PgIntervalToIso8601Xnull,
+	"money":               shared.PgMoneyToNumericXnull,
+	"bit":                 shared.PgBitStringPrintQuotedHexXnull,
+	"varbit":              shared.PgBitStringPrintQuotedHexXnull,
+	"bit varying":         shared.PgBitStringPrintQuotedHexXnull,
+	"mysql_geometry":      shared.MysqlGeometryToWktXnull,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
+	"xml":                    shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"smallmoney":             shared.PgMoneyToNumericXnull,
+	"sql_variant":            shared.CastToStringPrintQuotedEscapedLiteralXnull,
+	"hierarchyid":            shared.CastToBytesCastToStringPrintQuotedHexXnull,
+	"rowversion":             shared.CastToBytesCastToStringPrintQuotedHexXnull,
+}
+
+var SnowflakeConvertedValues = map[string]bool{
+	"SQL_BIT":        true,
+	"array":          true,
+	"bit":            true,
+	"bit varying":    true,
+	"bool[]":         true,
+	"float4[]":       true,
+	"float8[]":       true,
+	"int2[]":         true,
+	"int4[]":         true,
+	"int8[]":         true,
+	"interval":       true,
+	"money":          true,
+	"mysql_geometry": true,
+	"numeric[]":      true,
+	"oid[]":          true,
+	"smallmoney":     true,
+	"varbit":         true,
 }
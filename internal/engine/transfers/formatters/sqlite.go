@@ -0,0 +1,164 @@
+package formatters
+
+import (
+	"database/sql"
+
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+var SqliteCreateFormatters = map[string]func(column *sql.ColumnType, terminator string) (string, error){
+	"SQL_UNKNOWN_TYPE":    shared.TextCreateFormatter,
+	"SQL_CHAR":            shared.TextCreateFormatter,
+	"SQL_NUMERIC":         shared.SqliteNumericCreateFormatter,
+	"SQL_DECIMAL":         shared.SqliteNumericCreateFormatter,
+	"SQL_INTEGER":         shared.IntegerCreateFormatter,
+	"SQL_SMALLINT":        shared.IntegerCreateFormatter,
+	"SQL_FLOAT":           shared.RealCreateFormatter,
+	"SQL_REAL":            shared.RealCreateFormatter,
+	"SQL_DOUBLE":          shared.RealCreateFormatter,
+	"SQL_DATETIME":        shared.TextCreateFormatter,
+	"SQL_TIME":            shared.TextCreateFormatter,
+	"SQL_VARCHAR":         shared.TextCreateFormatter,
+	"SQL_TYPE_DATE":       shared.TextCreateFormatter,
+	"SQL_TYPE_TIME":       shared.TextCreateFormatter,
+	"SQL_TYPE_TIMESTAMP":  shared.TextCreateFormatter,
+	"SQL_TIMESTAMP":       shared.TextCreateFormatter,
+	"SQL_LONGVARCHAR":     shared.TextCreateFormatter,
+	"SQL_BINARY":          shared.BlobCreateFormatter,
+	"SQL_VARBINARY":       shared.BlobCreateFormatter,
+	"SQL_LONGVARBINARY":   shared.BlobCreateFormatter,
+	"SQL_BIGINT":          shared.IntegerCreateFormatter,
+	"SQL_TINYINT":         shared.IntegerCreateFormatter,
+	"SQL_BIT":             shared.IntegerCreateFormatter,
+	"SQL_WCHAR":           shared.TextCreateFormatter,
+	"SQL_WVARCHAR":        shared.TextCreateFormatter,
+	"SQL_WLONGVARCHAR":    shared.TextCreateFormatter,
+	"SQL_GUID":            shared.TextCreateFormatter,
+	"SQL_SIGNED_OFFSET":   shared.TextCreateFormatter,
+	"SQL_UNSIGNED_OFFSET": shared.TextCreateFormatter,
+	"SQL_SS_XML":          shared.TextCreateFormatter,
+	"SQL_SS_TIME2":        shared.TextCreateFormatter,
+	"SQL_SS_VARIANT":      shared.TextCreateFormatter,
+	"SQL_SS_UDT":          shared.BlobCreateFormatter,
+	"uniqueidentifier":    shared.TextCreateFormatter,
+	"uuid":                shared.TextCreateFormatter,
+	"xml":                 shared.TextCreateFormatter,
+	"json":                shared.TextCreateFormatter,
+	"jsonb":               shared.TextCreateFormatter,
+	"array":               shared.TextCreateFormatter,
+	"interval":            shared.TextCreateFormatter,
+	"money":               shared.SqliteNumericCreateFormatter,
+	"bit":                 shared.TextCreateFormatter,
+	"varbit":              shared.TextCreateFormatter,
+	"bit varying":         shared.TextCreateFormatter,
+	"mysql_geometry":      shared.TextCreateFormatter,
+	"tinyint unsigned":    shared.IntegerCreateFormatter,
+	"smallint unsigned":   shared.IntegerCreateFormatter,
+	"mediumint unsigned":  shared.IntegerCreateFormatter,
+	"int unsigned":        shared.IntegerCreateFormatter,
+	"integer unsigned":    shared.IntegerCreateFormatter,
+	"bigint unsigned":     shared.TextCreateFormatter,
+	"enum":                shared.TextCreateFormatter,
+	"set":                 shared.TextCreateFormatter,
+	"timestamptz":         shared.TextCreateFormatter,
+	"timestamp_tz":        shared.TextCreateFormatter,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.TextCreateFormatter,
+	"datetimeoffset":         shared.TextCreateFormatter,
+	"smallmoney":             shared.SqliteNumericCreateFormatter,
+	"sql_variant":            shared.TextCreateFormatter,
+	"hierarchyid":            shared.BlobCreateFormatter,
+	"rowversion":             shared.BlobCreateFormatter,
+
+	// Oracle
+	"timestamp with time zone":       shared.TextCreateFormatter,
+	"timestamp with local time zone": shared.TextCreateFormatter,
+	"binary_float":                   shared.RealCreateFormatter,
+	"binary_double":                  shared.RealCreateFormatter,
+}
+
+var SqliteValFormatters = map[string]func(value interface{}, terminator string) (formattedValue string, err error){
+	"SQL_UNKNOWN_TYPE":    shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_CHAR":            shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_NUMERIC":         shared.RawXnull,
+	"SQL_DECIMAL":         shared.RawXnull,
+	"SQL_INTEGER":         shared.RawXnull,
+	"SQL_SMALLINT":        shared.RawXnull,
+	"SQL_FLOAT":           shared.RawXnull,
+	"SQL_REAL":            shared.RawXnull,
+	"SQL_DOUBLE":          shared.RawXnull,
+	"SQL_DATETIME":        shared.CastToTimeFormatToIsoTimestampStringXnull,
+	"SQL_TIME":            shared.CastToTimeFormatToTimeStringXnull,
+	"SQL_VARCHAR":         shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_TYPE_DATE":       shared.CastToTimeFormatToIsoDateStringXnull,
+	"SQL_TYPE_TIME":       shared.CastToTimeFormatToTimeStringXnull,
+	"SQL_TYPE_TIMESTAMP":  shared.CastToTimeFormatToIsoTimestampStringXnull,
+	"SQL_TIMESTAMP":       shared.CastToTimeFormatToIsoTimestampStringXnull,
+	"SQL_LONGVARCHAR":     shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_BINARY":          shared.CastToBytesPrintMysqlHexXnull,
+	"SQL_VARBINARY":       shared.CastToBytesPrintMysqlHexXnull,
+	"SQL_LONGVARBINARY":   shared.CastToBytesPrintMysqlHexXnull,
+	"SQL_BIGINT":          shared.RawXnull,
+	"SQL_TINYINT":         shared.RawXnull,
+	"SQL_BIT":             shared.CastToBoolWriteBinaryEquivalentXnull,
+	"SQL_WCHAR":           shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_WVARCHAR":        shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_WLONGVARCHAR":    shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_GUID":            shared.QuotedXnull,
+	"SQL_SIGNED_OFFSET":   shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_UNSIGNED_OFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_XML":          shared.CastToStringPrintQuotedLiteralXnull,
+	"SQL_SS_TIME2":        shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_VARIANT":      shared.CastToBytesCastToStringPrintQuotedXnull,
+	"SQL_SS_UDT":          shared.CastToBytesPrintMysqlHexXnull,
+	"xml":                 shared.CastToStringPrintQuotedLiteralXnull,
+	"json":                shared.CastToStringPrintQuotedLiteralXnull,
+	"jsonb":               shared.CastToStringPrintQuotedLiteralXnull,
+	"array":               shared.PgTextArrayToJsonXnull,
+	"int2[]":              shared.PgNumericArrayToJsonXnull,
+	"int4[]":              shared.PgNumericArrayToJsonXnull,
+	"int8[]":              shared.PgNumericArrayToJsonXnull,
+	"float4[]":            shared.PgNumericArrayToJsonXnull,
+	"float8[]":            shared.PgNumericArrayToJsonXnull,
+	"numeric[]":           shared.PgNumericArrayToJsonXnull,
+	"oid[]":               shared.PgNumericArrayToJsonXnull,
+	"bool[]":              shared.PgBoolArrayToJsonXnull,
+	"interval":            shared.PgIntervalToIso8601Xnull,
+	"money":               shared.PgMoneyToNumericXnull,
+	"bit":                 shared.CastToStringPrintQuotedLiteralXnull,
+	"varbit":              shared.CastToStringPrintQuotedLiteralXnull,
+	"bit varying":         shared.CastToStringPrintQuotedLiteralXnull,
+	"mysql_geometry":      shared.MysqlGeometryToWktXnull,
+	"timestamptz":         shared.CastToTimeFormatToTimetampStringXnull,
+	"timestamp_tz":        shared.CastToTimeFormatToTimetampStringXnull,
+
+	// SQL Server
+	"SQL_SS_TIMESTAMPOFFSET": shared.CastToBytesCastToStringPrintQuotedXnull,
+	"datetimeoffset":         shared.CastToBytesCastToStringPrintQuotedXnull,
+	"smallmoney":             shared.PgMoneyToNumericXnull,
+	"sql_variant":            shared.CastToBytesCastToStringPrintQuotedXnull,
+	"hierarchyid":            shared.CastToBytesPrintMysqlHexXnull,
+	"rowversion":             shared.CastToBytesPrintMysqlHexXnull,
+
+	// Oracle
+	"timestamp with time zone":       shared.CastToTimeFormatToTimetampStringXnull,
+	"timestamp with local time zone": shared.CastToTimeFormatToTimetampStringXnull,
+}
+
+var SqliteConvertedValues = map[string]bool{
+	"SQL_BIT":        true,
+	"array":          true,
+	"bool[]":         true,
+	"float4[]":       true,
+	"float8[]":       true,
+	"int2[]":         true,
+	"int4[]":         true,
+	"int8[]":         true,
+	"interval":       true,
+	"money":          true,
+	"mysql_geometry": true,
+	"numeric[]":      true,
+	"oid[]":          true,
+	"smallmoney":     true,
+}
@@ -0,0 +1,212 @@
+package transfers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/sqlpipe/odbc"
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/dialects"
+)
+
+// parameterBatchSize is how many rows each parameter array insert sends.
+const parameterBatchSize = 1000
+
+// parameterBatchBytes caps the buffers the driver allocates to bind a batch.
+const parameterBatchBytes = 32 << 20
+
+// CheckParameterFormatters returns an error naming the first column whose
+// values have to be converted for the target, which the parameters insert
+// method doesn't do.
+func CheckParameterFormatters(dialect dialects.Dialect, columnNames []string, colDbTypes []string) error {
+	for i, colDbType := range colDbTypes {
+		if dialect.ConvertsValue(colDbType) {
+			return fmt.Errorf("column %v must be converted for %v targets, so it can't be inserted with parameters", columnNames[i], dialect.Name())
+		}
+	}
+	return nil
+}
+
+var binaryColumnTypes = map[string]bool{
+	"SQL_BINARY":        true,
+	"SQL_VARBINARY":     true,
+	"SQL_LONGVARBINARY": true,
+	"SQL_SS_UDT":        true,
+}
+
+// insertWithParameters writes rows to the target with one prepared insert,
+// binding batches of rows as parameter arrays instead of formatting them as
+// values. The driver converts each value to its target column's type.
+func insertWithParameters(
+	ctx context.Context,
+	db *sql.DB,
+	rows *sql.Rows,
+	insertQuery string,
+	colDbTypes []string,
+) error {
+	return catalog.WithOdbcConn(ctx, db, func(conn *odbc.Conn) error {
+		stmt, err := conn.PrepareContext(ctx, insertQuery)
+		if err != nil {
+			return fmt.Errorf("error preparing insert statement: %w", err)
+		}
+		defer stmt.Close()
+		batchStmt, ok := stmt.(*odbc.Stmt)
+		if !ok {
+			return errors.New("insert statement is not an odbc statement")
+		}
+
+		numCols := len(colDbTypes)
+		binary := make([]bool, numCols)
+		for i, colDbType := range colDbTypes {
+			sqlType, _ := odbc.SplitDatabaseTypeName(colDbType)
+			binary[i] = binaryColumnTypes[sqlType]
+		}
+
+		vals := make([]interface{}, numCols)
+		valPtrs := make([]interface{}, numCols)
+		for i := range vals {
+			valPtrs[i] = &vals[i]
+		}
+		row := make([]driver.Value, numCols)
+		batch := NewParameterBatch(numCols, parameterBatchSize, parameterBatchBytes)
+		send := func(columns [][]driver.Value) error {
+			_, err := batchStmt.ExecBatch(ctx, columns)
+			if err != nil {
+				return fmt.Errorf("error running batch insert statement: %w", err)
+			}
+			return nil
+		}
+
+		for rows.Next() {
+			err := rows.Scan(valPtrs...)
+			if err != nil {
+				return fmt.Errorf("error scanning source row: %w", err)
+			}
+			for i, val := range vals {
+				row[i], err = parameterValue(val, binary[i])
+				if err != nil {
+					return fmt.Errorf("error converting %v value %v: %w", colDbTypes[i], val, err)
+				}
+			}
+			err = batch.Add(row, send)
+			if err != nil {
+				return err
+			}
+		}
+		err = rows.Err()
+		if err != nil {
+			return fmt.Errorf("error reading source rows: %w", err)
+		}
+		return batch.Flush(send)
+	})
+}
+
+// ParameterBatch collects rows to bind as parameter arrays, one per column.
+// The driver sizes each column's buffer for every row at the width of the
+// column's longest value, so one long value makes the whole batch wide.
+type ParameterBatch struct {
+	maxRows  int
+	maxBytes int
+	columns  [][]driver.Value
+	longest  []int
+}
+
+func NewParameterBatch(numCols, maxRows, maxBytes int) *ParameterBatch {
+	return &ParameterBatch{
+		maxRows:  maxRows,
+		maxBytes: maxBytes,
+		columns:  make([][]driver.Value, numCols),
+		longest:  make([]int, numCols),
+	}
+}
+
+// Len is how many rows the batch holds.
+func (b *ParameterBatch) Len() int {
+	return len(b.columns[0])
+}
+
+// bytesWith is about how many bytes the driver needs to bind the batch with
+// extra rows of the given widths added.
+func (b *ParameterBatch) bytesWith(widths []int, extra int) int {
+	total := 0
+	for i, width := range widths {
+		if width < b.longest[i] {
+			width = b.longest[i]
+		}
+		total += width * (len(b.columns[i]) + extra)
+	}
+	return total
+}
+
+// Add appends row to the batch. If row would widen the batch past its byte
+// limit, the batch is sent first, and a batch that reaches either limit is
+// sent after the row is added, so a row over the byte limit by itself is
+// sent on its own.
+func (b *ParameterBatch) Add(row []driver.Value, send func(columns [][]driver.Value) error) error {
+	widths := make([]int, len(row))
+	for i, value := range row {
+		widths[i] = parameterWidth(value)
+	}
+	if b.Len() > 0 && b.bytesWith(widths, 1) > b.maxBytes {
+		err := b.Flush(send)
+		if err != nil {
+			return err
+		}
+	}
+	for i, value := range row {
+		b.columns[i] = append(b.columns[i], value)
+		if widths[i] > b.longest[i] {
+			b.longest[i] = widths[i]
+		}
+	}
+	if b.Len() >= b.maxRows || b.bytesWith(b.longest, 0) > b.maxBytes {
+		return b.Flush(send)
+	}
+	return nil
+}
+
+// Flush sends the rows in the batch, if there are any, and empties it.
+func (b *ParameterBatch) Flush(send func(columns [][]driver.Value) error) error {
+	if b.Len() == 0 {
+		return nil
+	}
+	err := send(b.columns)
+	if err != nil {
+		return err
+	}
+	for i := range b.columns {
+		b.columns[i] = b.columns[i][:0]
+		b.longest[i] = 0
+	}
+	return nil
+}
+
+// parameterValue converts a value read from the source to one the driver
+// can bind. Text comes from the driver as bytes, and is sent as a string so
+// it isn't written as binary.
+func parameterValue(val interface{}, binary bool) (driver.Value, error) {
+	value, err := driver.DefaultParameterConverter.ConvertValue(val)
+	if err != nil {
+		return nil, err
+	}
+	if b, ok := value.([]byte); ok && !binary {
+		return string(b), nil
+	}
+	return value, nil
+}
+
+// parameterWidth is about how many bytes the driver needs to bind value in a
+// parameter array. Strings are sent as UTF-16 with a terminator.
+func parameterWidth(value driver.Value) int {
+	switch value := value.(type) {
+	case string:
+		return 2 * (len(value) + 1)
+	case []byte:
+		return len(value)
+	default:
+		return 8
+	}
+}
@@ -0,0 +1,288 @@
+package transfers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/dialects"
+)
+
+// SchemaTransferReport is what happened to each table of a schema transfer,
+// and to the foreign keys between them.
+type SchemaTransferReport struct {
+	Tables      []TableResult      `json:"tables"`
+	ForeignKeys []ForeignKeyResult `json:"foreign_keys,omitempty"`
+	// Cycles lists groups of tables whose foreign keys refer to each other,
+	// which were loaded together with no order between them
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// TableResult reports how one table of a schema transfer went.
+type TableResult struct {
+	SourceTable string `json:"source_table"`
+	TargetTable string `json:"target_table"`
+	// Status is "succeeded", "failed", or "skipped" when an earlier failure
+	// stopped the transfer before the table started
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// ForeignKeyResult reports whether a foreign key was added to the target.
+type ForeignKeyResult struct {
+	Name            string `json:"name"`
+	Table           string `json:"table"`
+	ReferencedTable string `json:"referenced_table"`
+	// Status is "succeeded", "failed", or "skipped" when one of its tables
+	// didn't transfer or the target can't add foreign keys
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RunSchemaTransfer transfers each table of the source schema selected by the
+// include and exclude patterns, Concurrency tables at a time. Tables are loaded
+// after the tables their foreign keys refer to. Unless ContinueOnError is set,
+// the first failure keeps further tables from starting.
+func RunSchemaTransfer(
+	ctx context.Context,
+	schemaTransfer data.SchemaTransfer,
+) (
+	report SchemaTransferReport,
+	err error,
+) {
+	escape, err := catalog.SearchPatternEscape(ctx, schemaTransfer.Source.Db)
+	if err != nil {
+		return report, err
+	}
+	tables, err := catalog.ListTables(ctx, data.CatalogSearch{
+		Source: schemaTransfer.Source,
+		Schema: catalog.EscapeSearchPattern(schemaTransfer.SourceSchema, escape),
+	})
+	if err != nil {
+		return report, err
+	}
+	tables = selectTables(tables, schemaTransfer.Include, schemaTransfer.Exclude)
+
+	quote, err := catalog.IdentifierQuote(ctx, schemaTransfer.Source.Db)
+	if err != nil {
+		return report, err
+	}
+
+	foreignKeys, err := catalog.ListForeignKeys(ctx, schemaTransfer.Source.Db, tables)
+	if err != nil {
+		return report, err
+	}
+	levels, cycles := catalog.LoadOrder(tables, foreignKeys)
+	for _, cycle := range cycles {
+		names := []string{}
+		for _, table := range cycle {
+			names = append(names, table.Name)
+		}
+		report.Cycles = append(report.Cycles, names)
+	}
+
+	// tables are dropped up front, referring tables first, since on a re-run
+	// the foreign keys added last time keep referenced tables from dropping
+	if schemaTransfer.DropTargetTables {
+		err = dropTargetTables(ctx, schemaTransfer.Target, levels)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	concurrency := schemaTransfer.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	// stopped is closed on the first failure so no more tables start, while
+	// the ones already running finish
+	stopped := make(chan struct{})
+	var stopOnce sync.Once
+
+	report.Tables = make([]TableResult, len(tables))
+	// statuses are keyed by the source table, leaving out its type, which
+	// foreign keys don't have
+	statuses := map[catalog.Table]string{}
+	resultTables := make([]catalog.Table, len(tables))
+	semaphore := make(chan struct{}, concurrency)
+	i := 0
+	for _, level := range levels {
+		var wg sync.WaitGroup
+		for _, table := range level {
+			sourceTable := &data.SourceTable{Schema: table.Schema, Table: table.Name}
+			report.Tables[i] = TableResult{
+				SourceTable: table.QuotedName(quote),
+				TargetTable: table.Name,
+				Status:      "skipped",
+			}
+			result := &report.Tables[i]
+			resultTables[i] = catalog.Table{Catalog: table.Catalog, Schema: table.Schema, Name: table.Name}
+			i++
+
+			select {
+			case semaphore <- struct{}{}:
+			case <-stopped:
+				continue
+			}
+			select {
+			case <-stopped:
+				<-semaphore
+				continue
+			default:
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				target := schemaTransfer.Target
+				target.Table = result.TargetTable
+				start := time.Now()
+				err := RunTransfer(ctx, data.Transfer{
+					Source:            schemaTransfer.Source,
+					Target:            target,
+					SourceTable:       sourceTable,
+					CreateTargetTable: schemaTransfer.CreateTargetTables,
+					TypeMappings:      schemaTransfer.TypeMappings,
+					InsertMethod:      schemaTransfer.InsertMethod,
+				})
+				result.Duration = time.Since(start).String()
+				if err != nil {
+					result.Status = "failed"
+					result.Error = err.Error()
+					if !schemaTransfer.ContinueOnError {
+						stopOnce.Do(func() { close(stopped) })
+					}
+					return
+				}
+				result.Status = "succeeded"
+			}()
+		}
+		// the next level refers to this one, so it waits for it to finish
+		wg.Wait()
+	}
+	for i, result := range report.Tables {
+		statuses[resultTables[i]] = result.Status
+	}
+
+	if schemaTransfer.CreateForeignKeys {
+		report.ForeignKeys = addForeignKeys(ctx, schemaTransfer.Target, foreignKeys, statuses)
+	}
+
+	return report, nil
+}
+
+// dropTargetTables drops the target tables in reverse load order.
+func dropTargetTables(ctx context.Context, target data.Target, levels [][]catalog.Table) error {
+	dialect, ok := dialects.Get(target.SystemType)
+	if !ok {
+		return fmt.Errorf("unsupported target system type %v", target.SystemType)
+	}
+	dialect = dialects.ForTarget(ctx, dialect, target.Db)
+
+	for i := len(levels) - 1; i >= 0; i-- {
+		for j := len(levels[i]) - 1; j >= 0; j-- {
+			table := levels[i][j].Name
+			_, err := target.Db.ExecContext(ctx, dialect.DropTableCommand(dialects.TableName(dialect, target.Schema, table)))
+			if err != nil && !dialect.IsMissingTableError(err) {
+				return fmt.Errorf("error dropping target table %v: %w", table, err)
+			}
+		}
+	}
+	return nil
+}
+
+// addForeignKeys recreates the source's foreign keys between tables that
+// transferred successfully.
+func addForeignKeys(
+	ctx context.Context,
+	target data.Target,
+	foreignKeys []catalog.ForeignKey,
+	statuses map[catalog.Table]string,
+) []ForeignKeyResult {
+	dialect, _ := dialects.Get(target.SystemType)
+	identifiers := func(names []string) []string {
+		quoted := make([]string, len(names))
+		for i, name := range names {
+			quoted[i] = dialects.Identifier(dialect, name)
+		}
+		return quoted
+	}
+
+	results := []ForeignKeyResult{}
+	for _, foreignKey := range foreignKeys {
+		result := ForeignKeyResult{
+			Name:            foreignKey.Name,
+			Table:           foreignKey.Table.Name,
+			ReferencedTable: foreignKey.ReferencedTable.Name,
+			Status:          "skipped",
+		}
+		if statuses[foreignKey.Table] != "succeeded" || statuses[foreignKey.ReferencedTable] != "succeeded" {
+			results = append(results, result)
+			continue
+		}
+		name := ""
+		if foreignKey.Name != "" {
+			name = dialects.Identifier(dialect, foreignKey.Name)
+		}
+		command, ok := dialect.AddForeignKeyCommand(
+			dialects.TableName(dialect, target.Schema, result.Table),
+			name,
+			identifiers(foreignKey.Columns),
+			dialects.TableName(dialect, target.Schema, result.ReferencedTable),
+			identifiers(foreignKey.ReferencedColumns),
+		)
+		if !ok {
+			result.Error = fmt.Sprintf("%v targets can't add foreign keys", dialect.Name())
+			results = append(results, result)
+			continue
+		}
+		_, err := target.Db.ExecContext(ctx, command)
+		if err != nil {
+			result.Status = "failed"
+			result.Error = fmt.Sprintf("error running add foreign key command: %v", err)
+		} else {
+			result.Status = "succeeded"
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// selectTables keeps base tables that match an include pattern, or any name
+// when there are none, and no exclude pattern.
+func selectTables(tables []catalog.Table, include, exclude []string) []catalog.Table {
+	selected := []catalog.Table{}
+	for _, table := range tables {
+		if table.Type != "TABLE" && table.Type != "BASE TABLE" {
+			continue
+		}
+		if len(include) > 0 && !matchesAny(include, table.Name) {
+			continue
+		}
+		if matchesAny(exclude, table.Name) {
+			continue
+		}
+		selected = append(selected, table)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Name < selected[j].Name
+	})
+	return selected
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if catalog.MatchPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package transfers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+)
+
+// BuildSourceQuery writes the select for a source table, quoting identifiers
+// with quote. Condition values are returned as arguments for the query's
+// placeholders rather than written into it.
+func BuildSourceQuery(sourceTable data.SourceTable, quote string) (query string, args []any) {
+	columns := "*"
+	if len(sourceTable.Columns) > 0 {
+		quotedColumns := make([]string, len(sourceTable.Columns))
+		for i, column := range sourceTable.Columns {
+			quotedColumns[i] = catalog.QuoteIdentifier(column, quote)
+		}
+		columns = strings.Join(quotedColumns, ",")
+	}
+	table := catalog.Table{Schema: sourceTable.Schema, Name: sourceTable.Table}
+	query = fmt.Sprintf("select %v from %v", columns, table.QuotedName(quote))
+
+	conditions := []string{}
+	for _, condition := range sourceTable.Where {
+		column := catalog.QuoteIdentifier(condition.Column, quote)
+		switch condition.Operator {
+		case "is null", "is not null":
+			conditions = append(conditions, fmt.Sprintf("%v %v", column, condition.Operator))
+		case "in", "not in":
+			values, _ := condition.Value.([]any)
+			placeholders := make([]string, len(values))
+			for i, value := range values {
+				placeholders[i] = "?"
+				args = append(args, value)
+			}
+			conditions = append(conditions, fmt.Sprintf("%v %v (%v)", column, condition.Operator, strings.Join(placeholders, ",")))
+		default:
+			conditions = append(conditions, fmt.Sprintf("%v %v ?", column, condition.Operator))
+			args = append(args, condition.Value)
+		}
+	}
+	if len(conditions) > 0 {
+		query = fmt.Sprintf("%v where %v", query, strings.Join(conditions, " and "))
+	}
+	return query, args
+}
@@ -7,7 +7,10 @@ import (
 	"strings"
 
 	"github.com/sqlpipe/sqlpipe/internal/data"
+	"github.com/sqlpipe/sqlpipe/internal/engine/catalog"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/dialects"
 	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
 )
 
 func RunTransfer(
@@ -16,15 +19,25 @@ func RunTransfer(
 ) (
 	err error,
 ) {
-	rows, err := transfer.Source.Db.QueryContext(ctx, transfer.Query)
+	query := transfer.Query
+	var args []any
+	if transfer.SourceTable != nil {
+		quote, err := catalog.IdentifierQuote(ctx, transfer.Source.Db)
+		if err != nil {
+			return err
+		}
+		query, args = BuildSourceQuery(*transfer.SourceTable, quote)
+	}
+
+	rows, err := transfer.Source.Db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("error running query on source: %v", err.Error())
+		return fmt.Errorf("error running query on source: %w", err)
 	}
 	defer rows.Close()
 
 	columnNames, err := rows.Columns()
 	if err != nil {
-		return fmt.Errorf("error getting column names: %v", err.Error())
+		return fmt.Errorf("error getting column names: %w", err)
 	}
 
 	numCols := len(columnNames)
@@ -32,59 +45,94 @@ func RunTransfer(
 	vals := make([]interface{}, numCols)
 	valPtrs := make([]interface{}, numCols)
 
+	dialect, ok := dialects.Get(transfer.Target.SystemType)
+	if !ok {
+		return fmt.Errorf("unsupported target system type %v", transfer.Target.SystemType)
+	}
+	dialect = dialects.ForTarget(ctx, dialect, transfer.Target.Db)
+	dialect = dialects.WithTypeMappings(dialect, transfer.TypeMappings)
+
 	colTypes, err := rows.ColumnTypes()
 	if err != nil {
-		return fmt.Errorf("error getting column types: %v", err.Error())
+		return fmt.Errorf("error getting column types: %w", err)
 	}
 	colDbTypes := []string{}
 	for _, colType := range colTypes {
 		colDbTypes = append(colDbTypes, colType.DatabaseTypeName())
 	}
 
-	schemaSpecifier := ""
-	switch transfer.Target.Schema {
-	case "":
-	default:
-		schemaSpecifier = fmt.Sprintf("%v.", transfer.Target.Schema)
+	table := dialects.TableName(dialect, transfer.Target.Schema, transfer.Target.Table)
+	quotedColumnNames := make([]string, numCols)
+	for i, columnName := range columnNames {
+		quotedColumnNames[i] = dialects.Identifier(dialect, columnName)
+	}
+
+	createFormatters := make([]func(column *sql.ColumnType, terminator string) (string, error), numCols)
+	valFormatters := make([]func(value interface{}, terminator string) (string, error), numCols)
+	for i, colDbType := range colDbTypes {
+		createFormatters[i], ok = dialect.CreateFormatter(colDbType)
+		if !ok {
+			return fmt.Errorf("no %v create formatter for column type %v", dialect.Name(), colDbType)
+		}
+		valFormatters[i], ok = dialect.ValFormatter(colDbType)
+		if !ok {
+			return fmt.Errorf("no %v value formatter for column type %v", dialect.Name(), colDbType)
+		}
 	}
 
-	createFormatters := systemCreateFormatters[transfer.Target.SystemType]
+	if dialect.Name() == "postgresql" {
+		err = applyPostgisFallbacks(ctx, transfer.Target.Db, colDbTypes, createFormatters, valFormatters)
+		if err != nil {
+			return err
+		}
+	}
 
-	valFormatters := systemValFormatters[transfer.Target.SystemType]
+	if transfer.InsertMethod == "parameters" {
+		err = CheckParameterFormatters(dialect, columnNames, colDbTypes)
+		if err != nil {
+			return err
+		}
+	}
 
 	if transfer.DropTargetTable {
-		dropTableCommand := fmt.Sprintf(
-			"%v %v%v",
-			dropTableCommandStarters[transfer.Target.SystemType],
-			schemaSpecifier,
-			transfer.Target.Table,
-		)
-
-		_, err = transfer.Target.Db.ExecContext(ctx, dropTableCommand)
-		if err != nil {
-			return fmt.Errorf("error running drop table command: %v", err)
+		_, err = transfer.Target.Db.ExecContext(ctx, dialect.DropTableCommand(table))
+		if err != nil && !dialect.IsMissingTableError(err) {
+			return fmt.Errorf("error running drop table command: %w", err)
 		}
 	}
 
 	if transfer.CreateTargetTable {
-		createQuery := fmt.Sprintf("create table %v%v(", schemaSpecifier, transfer.Target.Table)
-
-		for i := 0; i < numCols-1; i++ {
-			columnSpecifier, err := createFormatters[colDbTypes[i]](colTypes[i], ",")
+		err = checkOrderBy(transfer.Target.OrderBy, columnNames)
+		if err != nil {
+			return err
+		}
+		if transfer.SourceTable != nil {
+			err = applyEnumChecks(ctx, transfer.Source.Db, *transfer.SourceTable, dialect, columnNames, quotedColumnNames, colDbTypes, createFormatters)
 			if err != nil {
-				return fmt.Errorf("error running %v formatter on value %v: %v", colDbTypes[i], colTypes[i], err)
+				return err
 			}
-			createQuery = createQuery + columnSpecifier
 		}
-		columnSpecifier, err := createFormatters[colDbTypes[numCols-1]](colTypes[numCols-1], ")")
-		if err != nil {
-			return fmt.Errorf("error running %v formatter on value %v: %v", colDbTypes[numCols-1], colTypes[numCols-1], err)
+		columnSpecifiers := make([]string, numCols)
+		for i := 0; i < numCols; i++ {
+			columnSpecifier, err := createFormatters[i](colTypes[i], "")
+			if err != nil {
+				return fmt.Errorf("error running %v formatter on value %v: %w", colDbTypes[i], colTypes[i], err)
+			}
+			// create formatters start with the column's bare name
+			columnSpecifiers[i] = quotedColumnNames[i] + strings.TrimPrefix(columnSpecifier, colTypes[i].Name())
 		}
-		createQuery = createQuery + columnSpecifier
+		orderBy := make([]string, len(transfer.Target.OrderBy))
+		for i, column := range transfer.Target.OrderBy {
+			orderBy[i] = dialects.Identifier(dialect, column)
+		}
+		createQuery := dialect.CreateTableCommand(table, columnSpecifiers, dialects.TableOptions{
+			Engine:  transfer.Target.Engine,
+			OrderBy: orderBy,
+		})
 
 		_, err = transfer.Target.Db.ExecContext(ctx, createQuery)
 		if err != nil {
-			return fmt.Errorf("error running create table command: %v", err)
+			return fmt.Errorf("error running create table command: %w", err)
 		}
 	}
 
@@ -94,69 +142,69 @@ func RunTransfer(
 		valPtrs[i] = &vals[i]
 	}
 
-	columnNamesString := strings.Join(columnNames, ",")
+	columnNamesString := strings.Join(quotedColumnNames, ",")
+
+	if transfer.InsertMethod == "parameters" {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", numCols), ",")
+		insertQuery := fmt.Sprintf("insert into %v (%v) values (%v)", table, columnNamesString, placeholders)
+		if selectExpressions, ok := getSelectExpressions(dialect, colDbTypes); ok {
+			insertQuery = fmt.Sprintf("insert into %v (%v) select %v from values (%v)", table, columnNamesString, selectExpressions, placeholders)
+		}
+		return insertWithParameters(ctx, transfer.Target.Db, rows, insertQuery, colDbTypes)
+	}
+
+	insertStarter, rowStarter, batchEnder := dialect.InsertSyntax(table, columnNamesString)
+	if selectExpressions, ok := getSelectExpressions(dialect, colDbTypes); ok {
+		insertStarter = fmt.Sprintf("insert into %v (%v) select %v from values (", table, columnNamesString, selectExpressions)
+	}
 
 	isFirstRow := true
 	dataRemaining := false
-	insertCheckType := insertCheckerTypes[transfer.Target.SystemType]
-	insertCheckNum := insertCheckerNums[transfer.Target.SystemType]
+	insertCheckType, insertCheckNum := dialect.BatchPolicy()
 
 	for i := 1; rows.Next(); i++ {
 		dataRemaining = true
 		rows.Scan(valPtrs...)
 
 		if isFirstRow {
-			batchBuilder.WriteString(fmt.Sprintf("insert into %v%v (%v) values (", schemaSpecifier, transfer.Target.Table, columnNamesString))
+			batchBuilder.WriteString(insertStarter)
 		} else {
-			batchBuilder.WriteString(",(")
+			batchBuilder.WriteString(rowStarter)
 		}
 		isFirstRow = false
 		for j := 0; j < numCols-1; j++ {
-			valToWrite, err := valFormatters[colDbTypes[j]](vals[j], ",")
+			valToWrite, err := valFormatters[j](vals[j], ",")
 			if err != nil {
-				return fmt.Errorf("error running %v formatter on mid-row value %v: %v", colDbTypes[j], vals[j], err)
+				return fmt.Errorf("error running %v formatter on mid-row value %v: %w", colDbTypes[j], vals[j], err)
 			}
 			batchBuilder.WriteString(valToWrite)
 		}
-		valToWrite, err := valFormatters[colDbTypes[numCols-1]](vals[numCols-1], ")")
+		valToWrite, err := valFormatters[numCols-1](vals[numCols-1], ")")
 		if err != nil {
-			return fmt.Errorf("error running %v formatter on row-end value %v: %v", colDbTypes[numCols-1], vals[numCols-1], err)
+			return fmt.Errorf("error running %v formatter on row-end value %v: %w", colDbTypes[numCols-1], vals[numCols-1], err)
 		}
 		batchBuilder.WriteString(valToWrite)
 
-		switch insertCheckType {
-		case "rows":
-			if i%insertCheckNum == 0 {
-				_, err := transfer.Target.Db.ExecContext(ctx, batchBuilder.String())
-				if err != nil {
-					return fmt.Errorf("error running mid-batch insert statement: %v", err)
-				}
-
-				batchBuilder.Reset()
-				isFirstRow = true
-				dataRemaining = false
-			}
-		default:
-			if batchBuilder.Len()%insertCheckNum == 0 {
-				_, err := transfer.Target.Db.ExecContext(ctx, batchBuilder.String())
-				if err != nil {
-					return fmt.Errorf("error running mid-batch insert statement: %v", err)
-				}
-
-				batchBuilder.Reset()
-				isFirstRow = true
-				dataRemaining = false
+		if BatchFull(insertCheckType, insertCheckNum, i, batchBuilder.Len()) {
+			batchBuilder.WriteString(batchEnder)
+			_, err := transfer.Target.Db.ExecContext(ctx, batchBuilder.String())
+			if err != nil {
+				return fmt.Errorf("error running mid-batch insert statement: %w", err)
 			}
-		}
 
+			batchBuilder.Reset()
+			isFirstRow = true
+			dataRemaining = false
+		}
 	}
 
 	if dataRemaining {
+		batchBuilder.WriteString(batchEnder)
 		stringToWrite := batchBuilder.String()
 
 		_, err := transfer.Target.Db.ExecContext(ctx, stringToWrite)
 		if err != nil {
-			return fmt.Errorf("error running batch-end insert statement: %v", err)
+			return fmt.Errorf("error running batch-end insert statement: %w", err)
 		}
 
 		batchBuilder.Reset()
@@ -165,35 +213,151 @@ func RunTransfer(
 	return nil
 }
 
-var (
-	systemCreateFormatters = map[string]map[string]func(column *sql.ColumnType, terminator string) (string, error){
-		"postgresql": formatters.PostgresqlCreateFormatters,
-		"mssql":      formatters.MssqlCreateFormatters,
-		"mysql":      formatters.MysqlCreateFormatters,
-		"snowflake":  formatters.SnowflakeCreateFormatters,
-	}
-	systemValFormatters = map[string]map[string]func(value interface{}, terminator string) (string, error){
-		"postgresql": formatters.PostgresqlValFormatters,
-		"mssql":      formatters.MssqlValFormatters,
-		"mysql":      formatters.MysqlValFormatters,
-		"snowflake":  formatters.SnowflakeValFormatters,
-	}
-	dropTableCommandStarters = map[string]string{
-		"postgresql": "drop table if exists",
-		"mssql":      "drop table if exists",
-		"mysql":      "drop table if exists",
-		"snowflake":  "drop table if exists",
-	}
-	insertCheckerTypes = map[string]string{
-		"postgresql": "length",
-		"mysql":      "length",
-		"mssql":      "rows",
-		"snowflake":  "rows",
-	}
-	insertCheckerNums = map[string]int{
-		"postgresql": 10000000,
-		"mysql":      4000000,
-		"mssql":      1000,
-		"snowflake":  3000,
+// applyPostgisFallbacks writes spatial columns as text when the PostgreSQL
+// target does not have PostGIS installed.
+func applyPostgisFallbacks(
+	ctx context.Context,
+	db *sql.DB,
+	colDbTypes []string,
+	createFormatters []func(column *sql.ColumnType, terminator string) (string, error),
+	valFormatters []func(value interface{}, terminator string) (string, error),
+) error {
+	postgisChecked := false
+	for i, colDbType := range colDbTypes {
+		createFormatter, ok := shared.Lookup(formatters.PostgresqlNoPostgisCreateFormatters, colDbType)
+		if !ok {
+			continue
+		}
+		if !postgisChecked {
+			var postgisAvailable bool
+			err := db.QueryRowContext(ctx, "select exists (select 1 from pg_extension where extname = 'postgis')").Scan(&postgisAvailable)
+			if err != nil {
+				return fmt.Errorf("error checking for postgis on target: %w", err)
+			}
+			if postgisAvailable {
+				return nil
+			}
+			postgisChecked = true
+		}
+		createFormatters[i] = createFormatter
+		valFormatters[i], _ = shared.Lookup(formatters.PostgresqlNoPostgisValFormatters, colDbType)
 	}
-)
+	return nil
+}
+
+// applyEnumChecks constrains the columns created for MySQL enums to the
+// enum's values, which are read from the source's information_schema since
+// the driver doesn't report them. Sets are left unconstrained, as their
+// values are combinations of the listed ones.
+func applyEnumChecks(
+	ctx context.Context,
+	db *sql.DB,
+	sourceTable data.SourceTable,
+	dialect dialects.Dialect,
+	columnNames []string,
+	quotedColumnNames []string,
+	colDbTypes []string,
+	createFormatters []func(column *sql.ColumnType, terminator string) (string, error),
+) error {
+	enumColumns := map[string]int{}
+	for i, colDbType := range colDbTypes {
+		if _, typeName := shared.TypeNames(colDbType); typeName == "enum" {
+			enumColumns[strings.ToLower(columnNames[i])] = i
+		}
+	}
+	if len(enumColumns) == 0 {
+		return nil
+	}
+
+	query := "select column_name, column_type from information_schema.columns where table_schema = database() and table_name = ?"
+	args := []any{sourceTable.Table}
+	if sourceTable.Schema != "" {
+		query = "select column_name, column_type from information_schema.columns where table_schema = ? and table_name = ?"
+		args = []any{sourceTable.Schema, sourceTable.Table}
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error reading enum values from source: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var columnName, columnType string
+		err = rows.Scan(&columnName, &columnType)
+		if err != nil {
+			return fmt.Errorf("error reading enum values from source: %w", err)
+		}
+		i, ok := enumColumns[strings.ToLower(columnName)]
+		if !ok {
+			continue
+		}
+		values, err := shared.MysqlEnumValues(columnType)
+		if err != nil {
+			return err
+		}
+		check, ok := dialect.CheckValuesConstraint(quotedColumnNames[i], values)
+		if !ok {
+			return nil
+		}
+		createFormatter := createFormatters[i]
+		createFormatters[i] = func(column *sql.ColumnType, terminator string) (string, error) {
+			columnSpecifier, err := createFormatter(column, "")
+			if err != nil {
+				return "", err
+			}
+			return columnSpecifier + check + terminator, nil
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("error reading enum values from source: %w", err)
+	}
+	return nil
+}
+
+// BatchFull reports whether a batch should be sent, given the dialect's batch
+// policy, the number of rows read so far and the length of the batch. Length
+// limited batches are sent once they reach the limit, so they can run past it
+// by up to one row.
+func BatchFull(checkType string, checkNum, rowsRead, batchLength int) bool {
+	if checkType == "rows" {
+		return rowsRead%checkNum == 0
+	}
+	return batchLength >= checkNum
+}
+
+// checkOrderBy makes sure every order by column is one of the result's columns,
+// since they are written into the create table command as is.
+func checkOrderBy(orderBy, columnNames []string) error {
+	for _, column := range orderBy {
+		found := false
+		for _, columnName := range columnNames {
+			if column == columnName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("order by column %v is not one of the query's columns: %v", column, strings.Join(columnNames, ", "))
+		}
+	}
+	return nil
+}
+
+// getSelectExpressions returns the select list used to insert values that
+// can't be written directly in a values clause, such as Snowflake's
+// parse_json, or false if every column can be inserted as is.
+func getSelectExpressions(dialect dialects.Dialect, colDbTypes []string) (string, bool) {
+	expressions := make([]string, len(colDbTypes))
+	needed := false
+	for i, colDbType := range colDbTypes {
+		column := fmt.Sprintf("column%v", i+1)
+		expression, ok := dialect.SelectExpression(colDbType)
+		if !ok {
+			expressions[i] = column
+			continue
+		}
+		expressions[i] = fmt.Sprintf(expression, column)
+		needed = true
+	}
+	return strings.Join(expressions, ","), needed
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+func TestTransactionOptions(t *testing.T) {
+	db, err := sql.Open("odbc", postgresqlTestSource.Dsn())
+	if err != nil {
+		t.Fatalf("error running sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("error getting connection: %v", err)
+	}
+	defer conn.Close()
+
+	isolation := func(querier interface {
+		QueryRowContext(context.Context, string, ...any) *sql.Row
+	}) string {
+		var level string
+		err := querier.QueryRowContext(ctx, "show transaction_isolation").Scan(&level)
+		if err != nil {
+			t.Fatalf("error getting isolation level: %v", err)
+		}
+		return level
+	}
+	defaultLevel := isolation(conn)
+
+	tests := []struct {
+		name      string
+		opts      *sql.TxOptions
+		wantLevel string
+		wantErr   bool
+	}{
+		{"default", &sql.TxOptions{}, defaultLevel, false},
+		{"serializable", &sql.TxOptions{Isolation: sql.LevelSerializable}, "serializable", false},
+		{"repeatable read", &sql.TxOptions{Isolation: sql.LevelRepeatableRead}, "repeatable read", false},
+		{"read only", &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}, "serializable", false},
+		{"unsupported", &sql.TxOptions{Isolation: sql.LevelSnapshot}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := conn.BeginTx(ctx, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wanted error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := isolation(tx); got != tt.wantLevel {
+				t.Errorf("wanted isolation level %v, got %v", tt.wantLevel, got)
+			}
+			var readOnly string
+			err = tx.QueryRowContext(ctx, "show transaction_read_only").Scan(&readOnly)
+			if err != nil {
+				t.Fatalf("error getting read only: %v", err)
+			}
+			if want := map[bool]string{true: "on", false: "off"}[tt.opts.ReadOnly]; readOnly != want {
+				t.Errorf("wanted transaction_read_only %v, got %v", want, readOnly)
+			}
+			err = tx.Commit()
+			if err != nil {
+				t.Fatalf("error committing: %v", err)
+			}
+			if got := isolation(conn); got != defaultLevel {
+				t.Errorf("wanted isolation level %v restored after commit, got %v", defaultLevel, got)
+			}
+		})
+	}
+}
+
+func TestConnector(t *testing.T) {
+	db, err := sql.Open("odbc", postgresqlTestSource.Dsn())
+	if err != nil {
+		t.Fatalf("error running sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	connector, err := db.Driver().(driver.DriverContext).OpenConnector(postgresqlTestSource.Dsn())
+	if err != nil {
+		t.Fatalf("error opening connector: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = connector.Connect(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("wanted context.Canceled, got %v", err)
+	}
+
+	connectorDb := sql.OpenDB(connector)
+	defer connectorDb.Close()
+	err = connectorDb.PingContext(context.Background())
+	if err != nil {
+		t.Fatalf("error pinging: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/sqlpipe/odbc"
+	"github.com/sqlpipe/sqlpipe/internal/engine/transfers/formatters/shared"
+)
+
+var splitDatabaseTypeNameTests = []struct {
+	databaseTypeName string
+	sqlType          string
+	typeName         string
+}{
+	{databaseTypeName: "SQL_VARCHAR:jsonb", sqlType: "SQL_VARCHAR", typeName: "jsonb"},
+	{databaseTypeName: "SQL_INTEGER", sqlType: "SQL_INTEGER", typeName: ""},
+	{databaseTypeName: "SQL_VARCHAR:a:b", sqlType: "SQL_VARCHAR", typeName: "a:b"},
+	{databaseTypeName: "", sqlType: "", typeName: ""},
+}
+
+func TestSplitDatabaseTypeName(t *testing.T) {
+	for _, tt := range splitDatabaseTypeNameTests {
+		tt := tt
+		t.Run(tt.databaseTypeName, func(t *testing.T) {
+			sqlType, typeName := odbc.SplitDatabaseTypeName(tt.databaseTypeName)
+			if sqlType != tt.sqlType || typeName != tt.typeName {
+				t.Fatalf("wanted %q, %q, got %q, %q", tt.sqlType, tt.typeName, sqlType, typeName)
+			}
+		})
+	}
+}
+
+var typeNamesTests = []struct {
+	colDbType string
+	sqlType   string
+	typeName  string
+}{
+	{colDbType: "SQL_VARCHAR:jsonb", sqlType: "SQL_VARCHAR", typeName: "jsonb"},
+	{colDbType: "SQL_VARCHAR", sqlType: "SQL_VARCHAR", typeName: ""},
+	{colDbType: "SQL_VARCHAR:_int4", sqlType: "SQL_VARCHAR", typeName: "int4[]"},
+	{colDbType: "SQL_TYPE_TIMESTAMP:TIMESTAMP(6) WITH TIME ZONE", sqlType: "SQL_TYPE_TIMESTAMP", typeName: "timestamp with time zone"},
+	{colDbType: "SQL_DECIMAL:numeric(10, 2)", sqlType: "SQL_DECIMAL", typeName: "numeric"},
+	{colDbType: "SQL_BIT:bool", sqlType: "SQL_BIT", typeName: ""},
+	{colDbType: "SQL_LONGVARBINARY:geometry", sqlType: "SQL_LONGVARBINARY", typeName: "mysql_geometry"},
+	{colDbType: "SQL_LONGVARBINARY:point", sqlType: "SQL_LONGVARBINARY", typeName: "mysql_geometry"},
+	{colDbType: "SQL_VARCHAR:geometry", sqlType: "SQL_VARCHAR", typeName: "geometry"},
+	{colDbType: "SQL_SS_UDT:geography", sqlType: "SQL_SS_UDT", typeName: "geography"},
+	{colDbType: "SQL_VARBINARY:bit", sqlType: "SQL_VARBINARY", typeName: ""},
+	{colDbType: "SQL_BINARY:timestamp", sqlType: "SQL_BINARY", typeName: "rowversion"},
+}
+
+func TestTypeNames(t *testing.T) {
+	for _, tt := range typeNamesTests {
+		tt := tt
+		t.Run(tt.colDbType, func(t *testing.T) {
+			sqlType, typeName := shared.TypeNames(tt.colDbType)
+			if sqlType != tt.sqlType || typeName != tt.typeName {
+				t.Fatalf("wanted %q, %q, got %q, %q", tt.sqlType, tt.typeName, sqlType, typeName)
+			}
+		})
+	}
+}
+
+var lookupFormatters = map[string]string{
+	"jsonb":       "native",
+	"int4[]":      "native array",
+	"array":       "array",
+	"SQL_VARCHAR": "generic",
+}
+
+var lookupTests = []struct {
+	colDbType string
+	expected  string
+	ok        bool
+}{
+	{colDbType: "SQL_VARCHAR:jsonb", expected: "native", ok: true},
+	{colDbType: "SQL_VARCHAR:_int4", expected: "native array", ok: true},
+	{colDbType: "SQL_VARCHAR:_text", expected: "array", ok: true},
+	{colDbType: "SQL_VARCHAR:citext", expected: "generic", ok: true},
+	{colDbType: "SQL_VARCHAR", expected: "generic", ok: true},
+	{colDbType: "SQL_INTEGER:int4", expected: "", ok: false},
+}
+
+func TestLookup(t *testing.T) {
+	for _, tt := range lookupTests {
+		tt := tt
+		t.Run(tt.colDbType, func(t *testing.T) {
+			result, ok := shared.Lookup(lookupFormatters, tt.colDbType)
+			if result != tt.expected || ok != tt.ok {
+				t.Fatalf("wanted %q, %v, got %q, %v", tt.expected, tt.ok, result, ok)
+			}
+		})
+	}
+}